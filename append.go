@@ -0,0 +1,123 @@
+package decstr
+
+import "unicode/utf8"
+
+// AppendConvert appends the decimal converted to the DecimalFormat to dst
+// and returns the extended buffer (see Convert for the formatting rules).
+// If the input is already normalized, no memory is allocated other than
+// the possible growth of dst.
+// If the input is not a valid decimal string, or if the DecimalFormat is not valid,
+// it returns dst unchanged and false.
+func (df DecimalFormat) AppendConvert(dst []byte, decimal []byte) ([]byte, bool) {
+	if df.Validate() != nil {
+		return dst, false
+	}
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return dst, false
+	}
+	return appendNormalized(dst, df, normalized)
+}
+
+// separators are the UTF-8 encoded separators of a DecimalFormat, used to convert the decimals to it.
+type separators struct {
+	point, group       [utf8.UTFMax]byte
+	pointLen, groupLen int // the length of the encoded separators, 0 for NoSeparator
+	size               int // the size of the groups before the last group of 3 digits
+}
+
+// encodeSeparators returns the encoded separators of the DecimalFormat.
+func encodeSeparators(df DecimalFormat) separators {
+	sep := separators{size: 3}
+	if df.Point != NoSeparator {
+		sep.pointLen = utf8.EncodeRune(sep.point[:], df.Point)
+	}
+	if df.Group != NoSeparator {
+		sep.groupLen = utf8.EncodeRune(sep.group[:], df.Group)
+	}
+	if !df.Standard {
+		sep.size = 2
+	}
+	return sep
+}
+
+// convertedLen returns the length in bytes of the normalized decimal
+// converted to the DecimalFormat.
+func convertedLen[T bytestr](df DecimalFormat, normalized T) int {
+	sep := encodeSeparators(df)
+	return separatedLen(&sep, normalized)
+}
+
+// appendNormalized appends the normalized decimal converted to the DecimalFormat to dst.
+// It returns dst unchanged and false if the decimal has a fractional part
+// but the DecimalFormat has no decimal separator.
+func appendNormalized[T bytestr](dst []byte, df DecimalFormat, normalized T) ([]byte, bool) {
+	sep := encodeSeparators(df)
+	return appendSeparated(dst, &sep, normalized)
+}
+
+// intEnd returns the end of the integer part of the normalized decimal.
+func intEnd[T bytestr](normalized T) int {
+	for i := 0; i < len(normalized); i++ {
+		if normalized[i] == '.' {
+			return i
+		}
+	}
+	return len(normalized)
+}
+
+// separatedLen returns the length in bytes of the normalized decimal written with the separators.
+func separatedLen[T bytestr](sep *separators, normalized T) int {
+	n := intEnd(normalized)
+	size := len(normalized)
+	if n < len(normalized) {
+		size += sep.pointLen - 1
+	}
+	if sep.groupLen == 0 {
+		return size
+	}
+	digits := n
+	if normalized[0] == '-' {
+		digits--
+	}
+	if digits > 3 {
+		size += (1 + (digits-4)/sep.size) * sep.groupLen
+	}
+	return size
+}
+
+// appendSeparated appends the normalized decimal written with the separators to dst.
+// It returns dst unchanged and false if the decimal has a fractional part
+// but there is no decimal separator.
+func appendSeparated[T bytestr](dst []byte, sep *separators, normalized T) ([]byte, bool) {
+	n := intEnd(normalized)
+	if n < len(normalized) && sep.pointLen == 0 {
+		return dst, false
+	}
+	i := 0
+	if normalized[0] == '-' {
+		dst = append(dst, '-')
+		i++
+	}
+	// the integer part, copied by groups: a grouping separator before the last 3 digits
+	// and every sep.size digits before them
+	if d := n - i; sep.groupLen > 0 && d > 3 {
+		// the size of the first group, then of the following ones
+		first := (d - 3) % sep.size
+		if first == 0 {
+			first = sep.size
+		}
+		for ; n-i > 3; first = sep.size {
+			dst = append(dst, normalized[i:i+first]...)
+			dst = append(dst, sep.group[:sep.groupLen]...)
+			i += first
+		}
+	}
+	dst = append(dst, normalized[i:n]...)
+	// the fractional part
+	if n < len(normalized) {
+		dst = append(dst, sep.point[:sep.pointLen]...)
+		dst = append(dst, normalized[n+1:]...)
+	}
+	return dst, true
+}
@@ -0,0 +1,108 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAppendConvert(t *testing.T) {
+	data := []struct {
+		df      DecimalFormat
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "123", "123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "+ 1234", "1 234", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "123456789", "12 34 56 789", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "-23456789", "-2 34 56 789", true},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " - 23 456 789,123", "-2,34,56,789·123", true},
+		{DecimalFormat{Point: ',', Group: '.', Standard: true}, "1.234", "1,234", true},
+		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "1234567.5", "1234567.5", true},
+		{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "1234.5", "", false},
+		{DecimalFormat{Point: '.', Group: '.', Standard: true}, "1234", "", false},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " -. ", "", false},
+	}
+
+	for _, test := range data {
+		got, ok := test.df.AppendConvert([]byte("x="), []byte(test.decimal))
+		if ok && string(got) != "x="+test.want || ok != test.ok || (!ok && string(got) != "x=") {
+			t.Errorf("(%v).AppendConvert(\"x=\", %q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, "x="+test.want, test.ok)
+		}
+		// compare with Convert
+		if converted, ok := test.df.Convert(test.decimal); ok != test.ok || (ok && converted != test.want) {
+			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, converted, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestAppendConvertAllocs(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	decimal := []byte("-1234567.891")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = df.AppendConvert(buf[:0], decimal)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendConvert allocates %v times, want 0", allocs)
+	}
+}
+
+func TestConvertedLen(t *testing.T) {
+	formats := []DecimalFormat{
+		{Point: '.', Group: NoSeparator, Standard: true},
+		{Point: ',', Group: '.', Standard: true},
+		{Point: '.', Group: ',', Standard: false},
+		{Point: '·', Group: '\'', Standard: true},
+		{Point: ',', Group: ' ', Standard: false},
+	}
+	for _, df := range formats {
+		for _, decimal := range []string{"0", "1", "-12", "123", "1234", "-12345", "123456", "1234567.89", "-0.5", "12345678901"} {
+			buf, _ := appendNormalized(nil, df, decimal)
+			if got := convertedLen(df, decimal); got != len(buf) {
+				t.Errorf("convertedLen(%v, %q) = %d, want %d", df, decimal, got, len(buf))
+			}
+		}
+	}
+}
+
+func TestConvertAllocs(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	allocs := testing.AllocsPerRun(100, func() {
+		df.Convert("-1234567.891")
+	})
+	if allocs != 1 {
+		t.Errorf("Convert allocates %v times, want 1", allocs)
+	}
+	df = DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}
+	allocs = testing.AllocsPerRun(100, func() {
+		df.Convert("-1234567.891")
+	})
+	if allocs != 0 {
+		t.Errorf("Convert to the normalized form allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	for i := 0; i < b.N; i++ {
+		df.Convert("-1234567.891")
+	}
+}
+
+func BenchmarkAppendConvert(b *testing.B) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	decimal := []byte("-1234567.891")
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, _ = df.AppendConvert(buf[:0], decimal)
+	}
+}
+
+func ExampleDecimalFormat_AppendConvert() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	buf := []byte("total: ")
+	buf, _ = df.AppendConvert(buf, []byte("1234567.89"))
+	fmt.Println(string(buf))
+	// Output: total: 1.234.567,89
+}
@@ -0,0 +1,109 @@
+package decstr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// NormalizeAll normalizes all the values like Parse does with the same options.
+// It returns the normalized values and the errors of the values that failed,
+// at the same indexes; errs is nil if all the values are valid.
+// The values are processed in parallel with the WithWorkers option.
+func NormalizeAll(values []string, opts ...Option) (normalized []string, errs []error) {
+	return NormalizeAllContext(context.Background(), values, opts...)
+}
+
+// NormalizeAllContext is like NormalizeAll, but stops processing the values once ctx is done:
+// the error of the remaining values is then the error of ctx.
+func NormalizeAllContext(ctx context.Context, values []string, opts ...Option) (normalized []string, errs []error) {
+	normalized = make([]string, len(values))
+	errs = make([]error, len(values))
+	forEach(ctx, len(values), newOptions(opts).workers, func(i int, err error) {
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		normalized[i], _, errs[i] = Parse(values[i], opts...)
+	})
+	return normalized, compactErrors(errs)
+}
+
+// ConvertAll converts all the values like Convert does with the same options.
+// It returns the converted values and the errors of the values that failed (wrapping ErrInvalid,
+// or ErrInvalidFormat if the DecimalFormat is not valid), at the same indexes;
+// errs is nil if all the values are converted.
+// The values are processed in parallel with the WithWorkers option.
+func (df DecimalFormat) ConvertAll(values []string, opts ...Option) (converted []string, errs []error) {
+	return df.ConvertAllContext(context.Background(), values, opts...)
+}
+
+// ConvertAllContext is like ConvertAll, but stops processing the values once ctx is done:
+// the error of the remaining values is then the error of ctx.
+func (df DecimalFormat) ConvertAllContext(ctx context.Context, values []string, opts ...Option) (converted []string, errs []error) {
+	converted = make([]string, len(values))
+	errs = make([]error, len(values))
+	if err := df.Validate(); err != nil {
+		for i := range values {
+			converted[i], errs[i] = "0", err
+		}
+		return converted, errs
+	}
+	forEach(ctx, len(values), newOptions(opts).workers, func(i int, err error) {
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		var ok bool
+		if converted[i], ok = df.Convert(values[i], opts...); !ok {
+			errs[i] = fmt.Errorf("%w: %q", ErrInvalid, values[i])
+		}
+	})
+	return converted, compactErrors(errs)
+}
+
+// ctxCheckInterval is the number of values processed between two checks of the context.
+const ctxCheckInterval = 256
+
+// forEach calls f for all the indexes from 0 to n-1, splitting them
+// in contiguous chunks processed by the given number of goroutines.
+// The second argument of f is nil, or the error of ctx once it is done
+// (the context is checked every ctxCheckInterval indexes).
+func forEach(ctx context.Context, n, workers int, f func(i int, err error)) {
+	workers = min(workers, n)
+	if workers <= 1 {
+		forRange(ctx, 0, n, f)
+		return
+	}
+	var wg sync.WaitGroup
+	size := (n + workers - 1) / workers
+	for start := 0; start < n; start += size {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			forRange(ctx, start, end, f)
+		}(start, min(start+size, n))
+	}
+	wg.Wait()
+}
+
+// forRange calls f for the indexes from start to end-1, see forEach.
+func forRange(ctx context.Context, start, end int, f func(i int, err error)) {
+	var err error
+	for i := start; i < end; i++ {
+		if err == nil && (i-start)%ctxCheckInterval == 0 {
+			err = ctx.Err()
+		}
+		f(i, err)
+	}
+}
+
+// compactErrors returns nil if all the errors are nil, and errs otherwise.
+func compactErrors(errs []error) []error {
+	for _, err := range errs {
+		if err != nil {
+			return errs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package decstr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestNormalizeAll(t *testing.T) {
+	values := []string{"1 234,5", "1,234", "-12", "abc", "0,5"}
+	want := []string{"1234.5", "", "-12", "", "0.5"}
+	wantErrs := []error{nil, ErrAmbiguous, nil, ErrInvalid, nil}
+	for _, workers := range []int{0, 1, 2, 10} {
+		got, errs := NormalizeAll(values, WithWorkers(workers))
+		if !slices.Equal(got, want) {
+			t.Errorf("NormalizeAll(%q) with %d workers = %q, want %q", values, workers, got, want)
+		}
+		if len(errs) != len(values) {
+			t.Fatalf("NormalizeAll(%q) with %d workers returns %d errors, want %d", values, workers, len(errs), len(values))
+		}
+		for i, err := range errs {
+			if !errors.Is(err, wantErrs[i]) || (wantErrs[i] == nil && err != nil) {
+				t.Errorf("NormalizeAll(%q) with %d workers: errs[%d] = %v, want %v", values, workers, i, err, wantErrs[i])
+			}
+		}
+	}
+	if _, errs := NormalizeAll([]string{"1", "2,5"}); errs != nil {
+		t.Errorf("NormalizeAll of valid values returns errors %v, want nil", errs)
+	}
+}
+
+func TestConvertAll(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	values := make([]string, 1000)
+	want := make([]string, len(values))
+	for i := range values {
+		values[i] = strconv.Itoa(i*1000) + ".5"
+		want[i], _ = df.Convert(values[i])
+	}
+	got, errs := df.ConvertAll(values, WithWorkers(8))
+	if !slices.Equal(got, want) || errs != nil {
+		t.Errorf("ConvertAll returns different values or errors %v", errs)
+	}
+
+	got, errs = df.ConvertAll([]string{"1234", "x"})
+	if !slices.Equal(got, []string{"1.234", "0"}) || len(errs) != 2 || errs[0] != nil || !errors.Is(errs[1], ErrInvalid) {
+		t.Errorf("ConvertAll = (%q, %v), want ([1.234 0], [<nil> ErrInvalid])", got, errs)
+	}
+
+	invalid := DecimalFormat{Point: ',', Group: ',', Standard: true}
+	_, errs = invalid.ConvertAll([]string{"1"})
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidFormat) {
+		t.Errorf("ConvertAll with an invalid format returns %v, want ErrInvalidFormat", errs)
+	}
+}
+
+func TestNormalizeAllContext(t *testing.T) {
+	values := make([]string, 2*ctxCheckInterval)
+	for i := range values {
+		values[i] = "1 234,5"
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs := NormalizeAllContext(ctx, values, WithWorkers(2))
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("NormalizeAllContext with a canceled context: errs[%d] = %v, want context.Canceled", i, err)
+		}
+	}
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	_, errs = df.ConvertAllContext(ctx, values)
+	if len(errs) != len(values) || !errors.Is(errs[len(values)-1], context.Canceled) {
+		t.Errorf("ConvertAllContext with a canceled context returns %d errors, want %d context.Canceled", len(errs), len(values))
+	}
+	if _, errs := NormalizeAllContext(context.Background(), values); errs != nil {
+		t.Errorf("NormalizeAllContext returns errors %v, want nil", errs)
+	}
+}
+
+func ExampleNormalizeAll() {
+	normalized, errs := NormalizeAll([]string{"1 234,5", "12.5", "1,234"}, WithWorkers(4))
+	for i := range normalized {
+		fmt.Printf("%q %v\n", normalized[i], errs[i])
+	}
+	// Output:
+	// "1234.5" <nil>
+	// "12.5" <nil>
+	// "" decstr: ambiguous decimal format: "1,234"
+}
@@ -0,0 +1,94 @@
+package decstr
+
+import "strings"
+
+// compactSuffixes maps the suffixes of the compact (short scale) notation to their power of 10.
+// The trailing '.' of abbreviations (e.g. "Mio.") is removed before the lookup.
+var compactSuffixes = map[string]int{
+	"k": 3, "K": 3, "Tsd": 3, "tsd": 3,
+	"M": 6, "Mio": 6, "mn": 6,
+	"B": 9, "Bn": 9, "bn": 9, "Md": 9, "Mrd": 9,
+	"T": 12, "Tn": 12, "tn": 12, "Bio": 12,
+}
+
+// ParseCompact parses a decimal string in compact notation, like "3.4M", "12k" or "1,2 Mio.",
+// and returns the exact normalized decimal ("3400000", "12000", "1200000").
+// The suffixes are k/K/Tsd (thousand), M/Mio/mn (million), B/Bn/bn/Md/Mrd (billion)
+// and T/Tn/tn/Bio (trillion). A string without suffix is accepted.
+// The boolean `ok` is false if the suffix is unknown or if the decimal part
+// is not a valid decimal string (see NormalizeCheck).
+func ParseCompact(s string) (value string, ok bool) {
+	return parseScaled(s, compactSuffixes)
+}
+
+// parseScaled parses a decimal string followed by a suffix from the table,
+// and returns the normalized decimal multiplied by 10 to the power of the suffix.
+func parseScaled(s string, suffixes map[string]int) (value string, ok bool) {
+	i := unitStart(s)
+	value, ok = NormalizeCheck(s[:i])
+	if !ok {
+		return "", false
+	}
+	suffix := strings.TrimSpace(s[i:])
+	if suffix == "" {
+		return value, true
+	}
+	k, ok := suffixes[suffix]
+	if !ok {
+		k, ok = suffixes[strings.TrimSuffix(suffix, ".")]
+	}
+	if !ok {
+		return "", false
+	}
+	return shiftPoint(value, k), true
+}
+
+// CompactSuffixes are the suffixes used by ConvertCompact for the thousands,
+// millions, billions and trillions (including the space before them, if any).
+type CompactSuffixes [4]string
+
+// Some common compact suffixes.
+var (
+	EnglishCompact = CompactSuffixes{"K", "M", "B", "T"}
+	FrenchCompact  = CompactSuffixes{" k", " M", " Md", " Bn"}
+	GermanCompact  = CompactSuffixes{" Tsd.", " Mio.", " Mrd.", " Bio."}
+)
+
+// ConvertCompact converts a decimal string to the compact notation using the DecimalFormat
+// and the suffixes, like "1.2M" for "1234567". The value is rounded (half away from zero)
+// to at most precision fraction digits. Values under 1000 have no suffix.
+// If the input string is not a valid decimal string, or if the DecimalFormat is not valid
+// (or has no decimal separator for a rounded value with a fraction), it returns "0" and false.
+// Example:
+//
+//	{`.`, `,`, standard}.ConvertCompact("1234567", 1, EnglishCompact)    => "1.2M", true
+//	{`,`, `.`, standard}.ConvertCompact("-999950", 1, GermanCompact)     => "-1 Mio.", true
+func (df DecimalFormat) ConvertCompact(decimal string, precision int, suffixes CompactSuffixes) (new string, ok bool) {
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return "0", false
+	}
+	digits := len(normalized)
+	if i := strings.IndexByte(normalized, '.'); i >= 0 {
+		digits = i
+	}
+	if normalized[0] == '-' {
+		digits--
+	}
+	// the power of 1000 of the suffix
+	p := min((digits-1)/3, len(suffixes))
+	rounded := roundFrac(shiftPoint(normalized, -3*p), precision)
+	// the rounding can produce 1000 (e.g. 999.95 => 1000)
+	if p < len(suffixes) && strings.TrimPrefix(rounded, "-") == "1000" {
+		p++
+		rounded = roundFrac(shiftPoint(normalized, -3*p), precision)
+	}
+	new, ok = df.Convert(rounded)
+	if !ok {
+		return new, false
+	}
+	if p > 0 {
+		new += suffixes[p-1]
+	}
+	return new, true
+}
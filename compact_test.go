@@ -0,0 +1,82 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseCompact(t *testing.T) {
+	tests := []struct {
+		s     string
+		value string
+		ok    bool
+	}{
+		{"", "", false},
+		{"M", "", false},
+		{"12", "12", true},
+		{"12k", "12000", true},
+		{"3.4M", "3400000", true},
+		{"1,2 Mio.", "1200000", true},
+		{"-2,5 Mrd", "-2500000000", true},
+		{"1.5 bn", "1500000000", true},
+		{"0,01 T", "10000000000", true},
+		{"1,5 Tsd.", "1500", true},
+		{"12 kg", "", false},
+		{"1,234k", "", false}, // ambiguous
+	}
+
+	for _, test := range tests {
+		value, ok := ParseCompact(test.s)
+		if value != test.value || ok != test.ok {
+			t.Errorf("ParseCompact(%q) = (%q, %v), want (%q, %v)", test.s, value, ok, test.value, test.ok)
+		}
+	}
+}
+
+func TestConvertCompact(t *testing.T) {
+	var (
+		en = DecimalFormat{Point: '.', Group: ',', Standard: true}
+		de = DecimalFormat{Point: ',', Group: '.', Standard: true}
+	)
+	tests := []struct {
+		df        DecimalFormat
+		decimal   string
+		precision int
+		suffixes  CompactSuffixes
+		want      string
+		ok        bool
+	}{
+		{en, "0", 1, EnglishCompact, "0", true},
+		{en, "999.95", 1, EnglishCompact, "1K", true},
+		{en, "999.94", 1, EnglishCompact, "999.9", true},
+		{en, "1234", 1, EnglishCompact, "1.2K", true},
+		{en, "1234567", 1, EnglishCompact, "1.2M", true},
+		{en, "1250000", 1, EnglishCompact, "1.3M", true},
+		{en, "1234567", 0, EnglishCompact, "1M", true},
+		{en, "-999950", 1, EnglishCompact, "-1M", true},
+		{en, "12345678901", 2, EnglishCompact, "12.35B", true},
+		{en, "1234567890123456", 1, EnglishCompact, "1,234.6T", true},
+		{de, "1 234 567,8", 1, GermanCompact, "1,2 Mio.", true},
+		{en, "abc", 1, EnglishCompact, "0", false},
+		{DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, "1234", 1, EnglishCompact, "0", false},
+		{DecimalFormat{Point: ',', Group: ',', Standard: true}, "1234", 1, EnglishCompact, "0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.df.ConvertCompact(test.decimal, test.precision, test.suffixes)
+		if got != test.want || ok != test.ok {
+			t.Errorf("(%v).ConvertCompact(%q, %d) = (%q, %v), want (%q, %v)", test.df, test.decimal, test.precision, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleDecimalFormat_ConvertCompact() {
+	value, _ := ParseCompact("1,2 Mio.")
+	fmt.Println(value)
+	en := DecimalFormat{Point: '.', Group: ',', Standard: true}
+	compact, _ := en.ConvertCompact("1234567", 1, EnglishCompact)
+	fmt.Println(compact)
+	// Output:
+	// 1200000
+	// 1.2M
+}
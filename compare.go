@@ -0,0 +1,144 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// compareNormalized compares two normalized decimals (see IsNormalized) by value.
+// It returns -1 if a < b, 0 if a == b and +1 if a > b.
+func compareNormalized(a, b string) int {
+	negA, negB := strings.HasPrefix(a, "-"), strings.HasPrefix(b, "-")
+	switch {
+	case negA && negB:
+		return compareAbs(b[1:], a[1:])
+	case negA:
+		return -1
+	case negB:
+		return 1
+	}
+	return compareAbs(a, b)
+}
+
+// compareAbs compares two unsigned normalized decimals.
+func compareAbs(a, b string) int {
+	intA, fracA, _ := strings.Cut(a, ".")
+	intB, fracB, _ := strings.Cut(b, ".")
+	// without leading zeros, the longer integer part is the larger one
+	if c := len(intA) - len(intB); c != 0 {
+		return max(-1, min(1, c))
+	}
+	if c := strings.Compare(intA, intB); c != 0 {
+		return c
+	}
+	// without trailing zeros, the fractional parts compare as strings
+	return strings.Compare(fracA, fracB)
+}
+
+// InRange checks if the decimal string is between min and max (inclusive), comparing
+// their exact values without float conversion. The three strings are parsed with Parse,
+// so they can use different formats, and a blank bound means no bound.
+// It returns the error of Parse for the decimal or for a bound.
+// Example:
+//
+//	InRange("12,5", "0", "1 000 000,00") => true, nil
+//	InRange("-0.5", "0", "")             => false, nil
+func InRange(s, min, max string) (bool, error) {
+	value, _, err := Parse(s)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(min) != "" {
+		bound, _, err := Parse(min)
+		if err != nil {
+			return false, fmt.Errorf("decstr: min bound: %w", err)
+		}
+		if compareNormalized(value, bound) < 0 {
+			return false, nil
+		}
+	}
+	if strings.TrimSpace(max) != "" {
+		bound, _, err := Parse(max)
+		if err != nil {
+			return false, fmt.Errorf("decstr: max bound: %w", err)
+		}
+		if compareNormalized(value, bound) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Equal checks if two decimal strings denote the same number, whatever their formats:
+// "1.234,50" and "1,234.5" are equal. The strings are parsed with Parse,
+// and the error of the first invalid one is returned.
+func Equal(a, b string) (bool, error) {
+	normalizedA, _, err := Parse(a)
+	if err != nil {
+		return false, err
+	}
+	normalizedB, _, err := Parse(b)
+	if err != nil {
+		return false, err
+	}
+	return normalizedA == normalizedB, nil
+}
+
+// Min returns the smallest of the decimal strings, comparing their exact values:
+// value is the original string and normalized its normalized form, so that the caller
+// selects the one to display. The values are parsed with Parse and may use different formats.
+// If several values are the smallest, the first one is returned.
+// It returns an error wrapping ErrInvalid if there are no values,
+// or the error of Parse for the first invalid value, with its index.
+func Min(values ...string) (value, normalized string, err error) {
+	return extreme(values, -1)
+}
+
+// Max returns the largest of the decimal strings, see Min.
+func Max(values ...string) (value, normalized string, err error) {
+	return extreme(values, 1)
+}
+
+// UniqueByValue removes the values denoting the same number as a previous one, whatever their
+// formats: "1 234,50" is a duplicate of "1,234.5". The kept values are returned unchanged, in order.
+// The values that cannot be parsed (see Parse), like the ambiguous ones, are compared as written.
+// The report merged has the length of values: values[i] is merged into unique[merged[i]].
+// Example:
+//
+//	UniqueByValue([]string{"1,234.5", "7", "1 234,50", "x"}) => ["1,234.5" "7" "x"], [0 1 0 2]
+func UniqueByValue(values []string) (unique []string, merged []int) {
+	merged = make([]int, len(values))
+	seen := make(map[string]int, len(values))
+	for i, v := range values {
+		key, _, err := Parse(v)
+		if err != nil {
+			// the keys of the unparsed values cannot collide with the normalized ones
+			key = "!" + v
+		}
+		j, ok := seen[key]
+		if !ok {
+			j = len(unique)
+			seen[key] = j
+			unique = append(unique, v)
+		}
+		merged[i] = j
+	}
+	return unique, merged
+}
+
+// extreme returns the smallest (sign -1) or the largest (sign 1) of the values.
+func extreme(values []string, sign int) (value, normalized string, err error) {
+	if len(values) == 0 {
+		return "", "", fmt.Errorf("%w: no values", ErrInvalid)
+	}
+	for i, v := range values {
+		n, _, err := Parse(v)
+		if err != nil {
+			return "", "", fmt.Errorf("decstr: value %d: %w", i, err)
+		}
+		if i == 0 || compareNormalized(n, normalized)*sign > 0 {
+			value, normalized = v, n
+		}
+	}
+	return value, normalized, nil
+}
@@ -0,0 +1,166 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestCompareNormalized(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0", "0", 0},
+		{"1", "0", 1},
+		{"-1", "0", -1},
+		{"-1", "-2", 1},
+		{"12", "9", 1},
+		{"12.5", "12.49", 1},
+		{"12.5", "12.51", -1},
+		{"0.05", "0.5", -1},
+		{"-0.05", "-0.5", 1},
+		{"1234.5", "1234.5", 0},
+		{"100", "99.999", 1},
+		{"-100", "99.999", -1},
+	}
+
+	for _, test := range tests {
+		if got := compareNormalized(test.a, test.b); got != test.want {
+			t.Errorf("compareNormalized(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := compareNormalized(test.b, test.a); got != -test.want {
+			t.Errorf("compareNormalized(%q, %q) = %d, want %d", test.b, test.a, got, -test.want)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		s, min, max string
+		want        bool
+		err         error
+	}{
+		{"12,5", "0", "1 000 000,00", true, nil},
+		{"1 000 000,00", "0", "1 000 000,00", true, nil},
+		{"1 000 000,01", "0", "1 000 000,00", false, nil},
+		{"0", "0", "1", true, nil},
+		{"-0,5", "0", "", false, nil},
+		{"-0,5", "", "0", true, nil},
+		{"1,234.5", "1 234,5", "1 234,5", true, nil},
+		{"abc", "0", "1", false, ErrInvalid},
+		{"1", "1,234", "2", false, ErrAmbiguous},
+		{"1", "0", "x", false, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := InRange(test.s, test.min, test.max)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("InRange(%q, %q, %q) = (%v, %v), want (%v, %v)", test.s, test.min, test.max, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleInRange() {
+	for _, amount := range []string{"999 999,99", "1.000.000,01", "-5"} {
+		ok, _ := InRange(amount, "0", "1 000 000,00")
+		fmt.Println(amount, ok)
+	}
+	// Output:
+	// 999 999,99 true
+	// 1.000.000,01 false
+	// -5 false
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+		err  error
+	}{
+		{"1.234,50", "1,234.5", true, nil},
+		{"1 234,5", "1234.50", true, nil},
+		{"-0,00", "0", true, nil},
+		{"+12", "12,0", true, nil},
+		{"12,5", "12.05", false, nil},
+		{"-1", "1", false, nil},
+		{"1,234", "1234", false, ErrAmbiguous},
+		{"1", "abc", false, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := Equal(test.a, test.b)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Equal(%q, %q) = (%v, %v), want (%v, %v)", test.a, test.b, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleEqual() {
+	equal, _ := Equal("1.234,50", "1,234.5")
+	fmt.Println(equal)
+	// Output: true
+}
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		values         []string
+		min, minNormal string
+		max, maxNormal string
+		err            error
+	}{
+		{[]string{"12,5", "1 234,5", "-3", "0,75"}, "-3", "-3", "1 234,5", "1234.5", nil},
+		{[]string{"7"}, "7", "7", "7", "7", nil},
+		{[]string{"1,5", "1.50", "1,2"}, "1,2", "1.2", "1,5", "1.5", nil},
+		{[]string{"-0,05", "-0.5"}, "-0.5", "-0.5", "-0,05", "-0.05", nil},
+		{nil, "", "", "", "", ErrInvalid},
+		{[]string{"1", "1,234"}, "", "", "", "", ErrAmbiguous},
+	}
+
+	for _, test := range tests {
+		value, normalized, err := Min(test.values...)
+		if value != test.min || normalized != test.minNormal || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Min(%q) = (%q, %q, %v), want (%q, %q, %v)", test.values, value, normalized, err, test.min, test.minNormal, test.err)
+		}
+		value, normalized, err = Max(test.values...)
+		if value != test.max || normalized != test.maxNormal || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Max(%q) = (%q, %q, %v), want (%q, %q, %v)", test.values, value, normalized, err, test.max, test.maxNormal, test.err)
+		}
+	}
+}
+
+func ExampleMax() {
+	value, normalized, _ := Max("12,5", "1 234,5", "-3")
+	fmt.Println(value, "=", normalized)
+	// Output: 1 234,5 = 1234.5
+}
+
+func TestUniqueByValue(t *testing.T) {
+	values := []string{"1,234.5", "7", "1 234,50", "x", "7,0", "1,234", "x", "1.234,5", "-0", "0"}
+	unique, merged := UniqueByValue(values)
+	if want := []string{"1,234.5", "7", "x", "1,234", "-0"}; !slices.Equal(unique, want) {
+		t.Errorf("UniqueByValue(%q) = %q, want %q", values, unique, want)
+	}
+	if want := []int{0, 1, 0, 2, 1, 3, 2, 0, 4, 4}; !slices.Equal(merged, want) {
+		t.Errorf("UniqueByValue(%q) merged = %v, want %v", values, merged, want)
+	}
+	if unique, merged := UniqueByValue(nil); unique != nil || len(merged) != 0 {
+		t.Errorf("UniqueByValue(nil) = (%q, %v), want (nil, [])", unique, merged)
+	}
+}
+
+func ExampleUniqueByValue() {
+	values := []string{"1,234.5", "7", "1 234,50", "7.00"}
+	unique, merged := UniqueByValue(values)
+	fmt.Println(unique)
+	for i, j := range merged {
+		if values[i] != unique[j] {
+			fmt.Printf("%q merged into %q\n", values[i], unique[j])
+		}
+	}
+	// Output:
+	// [1,234.5 7]
+	// "1 234,50" merged into "1,234.5"
+	// "7.00" merged into "7"
+}
@@ -0,0 +1,143 @@
+package decstr
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// FormatCounter accumulates the detected formats of decimal strings,
+// like the values of a column, to profile them.
+// It is safe for concurrent use, and its zero value is ready to use.
+type FormatCounter struct {
+	mu        sync.Mutex
+	formats   map[DecimalFormat]int // the number of values of each detected format
+	ambiguous map[rune]int          // the number of ambiguous values for each separator
+	invalid   int                   // the number of invalid values
+	std       int                   // the number of values proving the standard grouping
+}
+
+// FormatStats is the summary of the values added to a FormatCounter.
+type FormatStats struct {
+	Formats   map[DecimalFormat]int // the histogram of the detected formats
+	Ambiguous int                   // the number of ambiguous values, like "1,234"
+	Invalid   int                   // the number of invalid values
+	Total     int                   // the number of values (blank values are ignored)
+}
+
+// Add detects the format of a decimal string (see DetectFormats) and counts it.
+// The blank strings are ignored.
+func (c *FormatCounter) Add(decimal string) {
+	_, abs := getSign(decimal)
+	if len(abs) == 0 {
+		return
+	}
+	formats := DetectFormats(decimal)
+	// the standard grouping is proven only if there are at least two groups
+	proven := len(formats) == 1 && formats[0].Standard && formats[0].Group != NoSeparator &&
+		countGroup(abs, formats[0].Group) >= 2
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(formats)
+	if proven {
+		c.std++
+	}
+}
+
+// AddFormats counts a detection result, as returned by DetectFormats:
+// no format for an invalid value, one format, or two formats for an ambiguous value.
+func (c *FormatCounter) AddFormats(formats []DecimalFormat) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(formats)
+}
+
+// add is AddFormats without the lock.
+func (c *FormatCounter) add(formats []DecimalFormat) {
+	switch len(formats) {
+	case 0:
+		c.invalid++
+	case 1:
+		if c.formats == nil {
+			c.formats = make(map[DecimalFormat]int)
+		}
+		c.formats[formats[0]]++
+	default:
+		if c.ambiguous == nil {
+			c.ambiguous = make(map[rune]int)
+		}
+		// the ambiguous separator is the grouping one of the first interpretation
+		c.ambiguous[formats[0].Group]++
+	}
+}
+
+// Stats returns the summary of the values added so far.
+func (c *FormatCounter) Stats() FormatStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := FormatStats{Formats: maps.Clone(c.formats), Invalid: c.invalid}
+	if stats.Formats == nil {
+		stats.Formats = map[DecimalFormat]int{}
+	}
+	for _, n := range c.ambiguous {
+		stats.Ambiguous += n
+	}
+	stats.Total = stats.Ambiguous + stats.Invalid
+	for _, n := range c.formats {
+		stats.Total += n
+	}
+	return stats
+}
+
+// MostLikely returns the most likely format of the values added so far, as a column format.
+// Unlike DetectFormatAll, it tolerates some invalid or inconsistent values: the decimal and
+// the grouping separators are the ones used by most of the values, and the ambiguous values
+// are used only to find a missing separator (a separator that is not the decimal one is
+// the grouping one, and vice versa). The grouping is non-standard if more values prove it
+// than the standard one.
+// It returns an error wrapping ErrInvalid if there is no valid value,
+// and ErrAmbiguous if the valid values are all ambiguous and their separator cannot be resolved.
+func (c *FormatCounter) MostLikely() (DecimalFormat, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.formats) == 0 && len(c.ambiguous) == 0 {
+		return DecimalFormat{}, fmt.Errorf("%w: no valid values", ErrInvalid)
+	}
+	points, groups := map[rune]int{}, map[rune]int{}
+	nonStd := 0
+	for df, n := range c.formats {
+		points[df.Point] += n
+		groups[df.Group] += n
+		if !df.Standard {
+			nonStd += n
+		}
+	}
+	point := mostVoted(points, NoSeparator)
+	group := mostVoted(groups, point)
+	switch {
+	case point == NoSeparator && group == NoSeparator && len(c.ambiguous) > 0:
+		sep := mostVoted(c.ambiguous, NoSeparator)
+		return DecimalFormat{}, fmt.Errorf("%w: %q can be a decimal or a grouping separator", ErrAmbiguous, sep)
+	case point == NoSeparator:
+		point = mostVoted(c.ambiguous, group)
+	case group == NoSeparator:
+		group = mostVoted(c.ambiguous, point)
+	}
+	return DecimalFormat{Point: point, Group: group, Standard: nonStd <= c.std}, nil
+}
+
+// mostVoted returns the separator with the most votes, ignoring NoSeparator and except.
+// Ties are broken by the smallest rune, so that the result is deterministic.
+// It returns NoSeparator if there is no such separator.
+func mostVoted(votes map[rune]int, except rune) rune {
+	best, most := NoSeparator, 0
+	for sep, n := range votes {
+		if sep == NoSeparator || sep == except {
+			continue
+		}
+		if n > most || (n == most && sep < best) {
+			best, most = sep, n
+		}
+	}
+	return best
+}
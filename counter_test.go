@@ -0,0 +1,116 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+)
+
+func TestFormatCounter(t *testing.T) {
+	var (
+		fr    = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+		frDec = DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}
+		plain = DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}
+		in    = DecimalFormat{Point: '.', Group: ',', Standard: false}
+	)
+	tests := []struct {
+		values []string
+		stats  FormatStats
+		want   DecimalFormat
+		err    error
+	}{
+		{
+			values: []string{"1 234,5", "12,5", "1,234", "7", "abc", "", "  ", "3 456,75"},
+			stats:  FormatStats{Formats: map[DecimalFormat]int{fr: 2, frDec: 1, plain: 1}, Ambiguous: 1, Invalid: 1, Total: 6},
+			want:   fr,
+		},
+		{
+			// some inconsistent values do not change the column format
+			values: []string{"1 234,5", "2 345,5", "1.5"},
+			stats:  FormatStats{Formats: map[DecimalFormat]int{fr: 2, {Point: '.', Group: NoSeparator, Standard: true}: 1}, Total: 3},
+			want:   fr,
+		},
+		{
+			// the missing grouping separator is found with the ambiguous values
+			values: []string{"1,234", "12,5"},
+			stats:  FormatStats{Formats: map[DecimalFormat]int{frDec: 1}, Ambiguous: 1, Total: 2},
+			want:   frDec,
+		},
+		{
+			values: []string{"1.234", "5"},
+			stats:  FormatStats{Formats: map[DecimalFormat]int{plain: 1}, Ambiguous: 1, Total: 2},
+			err:    ErrAmbiguous,
+		},
+		{
+			values: []string{"12,34,567.5", "1,234,567.5", "12,34,567"},
+			stats: FormatStats{Formats: map[DecimalFormat]int{
+				in: 1,
+				{Point: NoSeparator, Group: ',', Standard: false}: 1,
+				{Point: '.', Group: ',', Standard: true}:          1,
+			}, Total: 3},
+			want: in,
+		},
+		{
+			values: []string{"abc", ""},
+			stats:  FormatStats{Formats: map[DecimalFormat]int{}, Invalid: 1, Total: 1},
+			err:    ErrInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		var c FormatCounter
+		for _, v := range test.values {
+			c.Add(v)
+		}
+		stats := c.Stats()
+		if !maps.Equal(stats.Formats, test.stats.Formats) || stats.Ambiguous != test.stats.Ambiguous ||
+			stats.Invalid != test.stats.Invalid || stats.Total != test.stats.Total {
+			t.Errorf("Stats() of %q = %v, want %v", test.values, stats, test.stats)
+		}
+		got, err := c.MostLikely()
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("MostLikely() of %q error = %v, want %v", test.values, err, test.err)
+			continue
+		}
+		if test.err == nil && got != test.want {
+			t.Errorf("MostLikely() of %q = %v, want %v", test.values, got, test.want)
+		}
+	}
+}
+
+func TestFormatCounterConcurrent(t *testing.T) {
+	var (
+		c  FormatCounter
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Add("1 234,5")
+				c.AddFormats(DetectFormats("1,234"))
+			}
+		}()
+	}
+	wg.Wait()
+	if stats := c.Stats(); stats.Total != 1600 || stats.Ambiguous != 800 {
+		t.Errorf("Stats() = %v, want 1600 values with 800 ambiguous", stats)
+	}
+}
+
+func ExampleFormatCounter() {
+	var c FormatCounter
+	for _, v := range []string{"1 234,5", "12,5", "1,234", "n/a", "3 456"} {
+		c.Add(v)
+	}
+	stats := c.Stats()
+	fmt.Println(stats.Total, stats.Ambiguous, stats.Invalid)
+	df, _ := c.MostLikely()
+	fmt.Println(df)
+	// Output:
+	// 5 1 1
+	// {`,`, ` `, standard}
+}
@@ -0,0 +1,45 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// minorUnits are the numbers of digits of the minor units of the ISO 4217 currencies
+// that do not have 2 of them (like the cents of USD).
+var minorUnits = map[string]int{
+	// no minor unit
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	// thousandths
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	// ten-thousandths
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnits returns the number of fraction digits of the ISO 4217 currency code
+// (case insensitive): 0 for "JPY", 3 for "BHD", and 2 for most of the currencies,
+// including the unknown ones.
+func MinorUnits(currencyCode string) int {
+	if n, ok := minorUnits[strings.ToUpper(currencyCode)]; ok {
+		return n
+	}
+	return 2
+}
+
+// RoundCurrency rounds the decimal string to the minor units of the ISO 4217 currency
+// (see MinorUnits) with the rounding mode, and returns a normalized decimal string.
+// The input does not need to be normalized; if it is not a valid decimal string,
+// the error wraps ErrInvalid.
+// Example:
+//
+//	RoundCurrency("1 234,567", "EUR", HalfAwayFromZero) => "1234.57", nil
+//	RoundCurrency("1234.5", "JPY", HalfEven)             => "1234", nil
+//	RoundCurrency("0.0125", "BHD", AwayFromZero)         => "0.013", nil
+func RoundCurrency(decimal, currencyCode string, mode RoundingMode) (string, error) {
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, decimal)
+	}
+	return roundMode(normalized, MinorUnits(currencyCode), mode), nil
+}
@@ -0,0 +1,45 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRoundCurrency(t *testing.T) {
+	tests := []struct {
+		decimal string
+		code    string
+		mode    RoundingMode
+		want    string
+		err     error
+	}{
+		{"1 234,567", "EUR", HalfAwayFromZero, "1234.57", nil},
+		{"1234.5", "JPY", HalfEven, "1234", nil},
+		{"1235.5", "jpy", HalfEven, "1236", nil},
+		{"0.0125", "BHD", AwayFromZero, "0.013", nil},
+		{"-0.0125", "KWD", Floor, "-0.013", nil},
+		{"19.999", "USD", TowardZero, "19.99", nil},
+		{"19.991", "XYZ", Ceiling, "20", nil},
+		{"12.5", "CLF", HalfEven, "12.5", nil},
+		{"1,234", "EUR", HalfEven, "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := RoundCurrency(test.decimal, test.code, test.mode)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("RoundCurrency(%q, %q, %d) = (%q, %v), want (%q, %v)", test.decimal, test.code, test.mode, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleRoundCurrency() {
+	for _, code := range []string{"EUR", "JPY", "BHD"} {
+		rounded, _ := RoundCurrency("1234.5675", code, HalfEven)
+		fmt.Println(code, rounded)
+	}
+	// Output:
+	// EUR 1234.57
+	// JPY 1235
+	// BHD 1234.568
+}
@@ -0,0 +1,106 @@
+package decstr
+
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// customFormats are the formats registered by RegisterFormat, in order.
+// They are protected by groupingMu, like possibleGrouping.
+var customFormats []DecimalFormat
+
+// RegisterFormat adds a custom format to the ones considered by the detection,
+// like a legacy format using ';' as decimal separator. The strings that the built-in
+// detection rejects are tried against the custom formats, in their registration order:
+// a string matches a custom format if all its separators are the ones of the format,
+// with the usual rules (grouping by 3, or 3 then 2 digits, and one decimal separator).
+// The strings accepted by the built-in detection are not affected: with the format
+// {';', '.'}, "1.234.567" is still detected as {`<none>`, `.`, standard}.
+// The separator pair of the format becomes valid, as with RegisterSeparatorPair.
+//
+// It returns an error wrapping ErrInvalidFormat if the format has no decimal separator,
+// uses identical separators, or uses a digit, a sign or a space as separator.
+// Registering an already registered format is a no-op.
+func RegisterFormat(df DecimalFormat) error {
+	switch {
+	case df.Point == NoSeparator:
+		return fmt.Errorf("%w: missing decimal separator in %v", ErrInvalidFormat, df)
+	case df.Point == df.Group:
+		return fmt.Errorf("%w: identical decimal and grouping separators %q", ErrInvalidFormat, df.Point)
+	case !isCustomSeparator(df.Point) || (df.Group != NoSeparator && !isCustomSeparator(df.Group)):
+		return fmt.Errorf("%w: invalid separator in %v", ErrInvalidFormat, df)
+	}
+	// the grouping style is detected, so it is not part of the registered format
+	df.Standard = true
+	groupingMu.Lock()
+	defer groupingMu.Unlock()
+	if slices.Contains(customFormats, df) {
+		return nil
+	}
+	customFormats = append(customFormats, df)
+	if _, ok := possibleGrouping[df.Point]; !ok {
+		possibleGrouping[df.Point] = []rune{}
+	}
+	if df.Group != NoSeparator && !slices.Contains(possibleGrouping[df.Point], df.Group) {
+		possibleGrouping[df.Point] = append(possibleGrouping[df.Point], df.Group)
+	}
+	return nil
+}
+
+// isCustomSeparator checks if r can be a separator of a custom format.
+func isCustomSeparator(r rune) bool {
+	return utf8.ValidRune(r) && (r < '0' || r > '9') && r != '-' && r != '+' && r != ' '
+}
+
+// scanCustom detects and normalizes the decimal string with the custom formats.
+// The separators of a custom format are replaced by the midpoint and the comma,
+// a valid pair for the built-in detection, that then checks the string.
+func scanCustom[T bytestr](decimal T) (normalized []byte, df DecimalFormat, ok bool) {
+	groupingMu.RLock()
+	formats := customFormats
+	groupingMu.RUnlock()
+	if len(formats) == 0 {
+		return nil, df, false
+	}
+	sign, abs := getSign(string(decimal))
+	for _, custom := range formats {
+		translated, ok := custom.translate(sign, abs)
+		if !ok {
+			continue
+		}
+		normalized, df, ok, _ = scanDecimal(translated, make([]byte, 0, len(translated)), make([]byte, 0, len(translated)), nil)
+		if !ok {
+			continue
+		}
+		if df.Point != NoSeparator {
+			df.Point = custom.Point
+		}
+		if df.Group != NoSeparator {
+			df.Group = custom.Group
+		}
+		return normalized, df, true
+	}
+	return nil, DecimalFormat{}, false
+}
+
+// translate returns the decimal string sign+abs written with the midpoint as decimal separator
+// and the comma as grouping separator, instead of the separators of the custom format df.
+// It returns false if abs has other characters than the digits and these separators.
+func (df DecimalFormat) translate(sign, abs string) ([]byte, bool) {
+	translated := make([]byte, 0, len(sign)+len(abs)+1)
+	translated = append(translated, sign...)
+	for _, r := range abs {
+		switch {
+		case '0' <= r && r <= '9':
+			translated = append(translated, byte(r))
+		case r == df.Point:
+			translated = append(translated, "·"...)
+		case r == df.Group && df.Group != NoSeparator:
+			translated = append(translated, ',')
+		default:
+			return nil, false
+		}
+	}
+	return translated, true
+}
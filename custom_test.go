@@ -0,0 +1,76 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegisterFormat(t *testing.T) {
+	// use separators unknown to the detection to not interfere with other tests
+	legacy := DecimalFormat{Point: '§', Group: '|', Standard: true}
+	if err := RegisterFormat(legacy); err != nil {
+		t.Fatalf("RegisterFormat(%v) = %v", legacy, err)
+	}
+	if err := RegisterFormat(DecimalFormat{Point: '§', Group: '|', Standard: false}); err != nil {
+		t.Fatalf("RegisterFormat(%v) twice = %v", legacy, err)
+	}
+	tests := []struct {
+		decimal string
+		want    string
+		df      DecimalFormat
+		ok      bool
+	}{
+		{"12§5", "12.5", DecimalFormat{Point: '§', Group: NoSeparator, Standard: true}, true},
+		{"1§234", "1.234", DecimalFormat{Point: '§', Group: NoSeparator, Standard: true}, true},
+		{" -1|234|567§25 ", "-1234567.25", legacy, true},
+		{"1|23|456§5", "123456.5", DecimalFormat{Point: '§', Group: '|', Standard: false}, true},
+		{"1|234", "", DecimalFormat{}, false}, // ambiguous
+		{"1|23§5", "", DecimalFormat{}, false},
+		{"1§2§3", "", DecimalFormat{}, false},
+		{"1,234§5", "", DecimalFormat{}, false},
+		{"1 234,5", "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, true},
+	}
+
+	for _, test := range tests {
+		got, ok := NormalizeCheck(test.decimal)
+		df, _ := DetectFormat(test.decimal)
+		if ok != test.ok || (ok && (got != test.want || df != test.df)) {
+			t.Errorf("NormalizeCheck(%q) = (%q, %v) with format %v, want (%q, %v) with format %v", test.decimal, got, ok, df, test.want, test.ok, test.df)
+		}
+		if IsDecimal(test.decimal) != test.ok {
+			t.Errorf("IsDecimal(%q) = %v, want %v", test.decimal, !test.ok, test.ok)
+		}
+		var p Parser
+		if got, _, ok := p.NormalizeString(test.decimal); ok != test.ok || (ok && got != test.want) {
+			t.Errorf("Parser.NormalizeString(%q) = (%q, %v), want (%q, %v)", test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+	if got, ok := legacy.Convert("-1234567.25"); got != "-1|234|567§25" || !ok {
+		t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, true)", legacy, "-1234567.25", got, ok, "-1|234|567§25")
+	}
+}
+
+func TestRegisterFormatErrors(t *testing.T) {
+	for _, df := range []DecimalFormat{
+		{Point: NoSeparator, Group: '~'},
+		{Point: ';', Group: ';'},
+		{Point: '5', Group: '~'},
+		{Point: ';', Group: '-'},
+		{Point: ';', Group: ' '},
+	} {
+		if err := RegisterFormat(df); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("RegisterFormat(%v) = %v, want %v", df, err, ErrInvalidFormat)
+		}
+	}
+}
+
+func ExampleRegisterFormat() {
+	// a legacy in-house format using '¦' as decimal separator
+	if err := RegisterFormat(DecimalFormat{Point: '¦', Group: '.'}); err != nil {
+		fmt.Println(err)
+	}
+	normalized, df, err := Parse("1.234¦5")
+	fmt.Println(normalized, df, err)
+	// Output: 1234.5 {`¦`, `.`, standard} <nil>
+}
@@ -1,452 +1,915 @@
-// decstr is a package for detecting and converting decimal strings.
-// It provides utilities for identifying decimal formats and converting between them.
-package decstr
-
-import (
-	"strings"
-)
-
-// NoSeparator represents the absence of a separator and is the 0 rune.
-const NoSeparator = rune(0)
-
-// DecimalFormat describes the format of a decimal string.
-//   - Point: The decimal separator (or NoSeparator if absent).
-//   - Group: The grouping separator (or NoSeparator if absent).
-//   - Standard: True if grouping follows a standard pattern (e.g., groups of 3 digits),
-//     False if it uses a non-standard pattern (e.g., 3 digits then 2 digits).
-type DecimalFormat struct {
-	Point    rune
-	Group    rune
-	Standard bool
-}
-
-// String returns a string representation of the DecimalFormat,
-// formatted as {`<Point>`, `<Group>`, <standard|non-standard>}.
-func (df DecimalFormat) String() string {
-	// sep converts a rune to its string representation or "<none>" if NoSeparator.
-	sep := func(r rune) string {
-		if r == NoSeparator {
-			return "<none>"
-		}
-		return string(r)
-	}
-	std := "non-standard"
-	if df.Standard {
-		std = "standard"
-	}
-	return "{`" + sep(df.Point) + "`, `" + sep(df.Group) + "`, " + std + "}"
-}
-
-// possibleGrouping maps each decimal separator to its valid grouping separators.
-// For example, ',' as a decimal separator may use ' ', '.', or '\” as grouping separators.
-var possibleGrouping = map[rune][]rune{
-	',':  {' ', '.', '\''},
-	'.':  {' ', ',', '\''},
-	'·':  {','},
-	'\'': {'.'},
-}
-
-// isPossible checks if the given grouping separator is valid for the specified decimal separator.
-func isPossible(point, group rune) bool {
-	groups, ok := possibleGrouping[point]
-	if !ok {
-		return false
-	}
-	for _, g := range groups {
-		if g == group {
-			return true
-		}
-	}
-	return false
-}
-
-// bytestr is a type constraint for []byte and string, used for functions
-// that operate generically on these types.
-type bytestr interface {
-	~[]byte | ~string
-}
-
-// trimLeft removes all leading occurrences of the specified character from the given byte slice or string.
-func trimLeft[T bytestr](decimal T, c byte) T {
-	var i int
-	for i = 0; i < len(decimal); i++ {
-		if decimal[i] != c {
-			break
-		}
-	}
-	return decimal[i:]
-}
-
-// trimRight removes all trailing occurrences of the specified character from the given byte slice or string.
-func trimRight[T bytestr](decimal T, c byte) T {
-	var i int
-	for i = len(decimal) - 1; i >= 0; i-- {
-		if decimal[i] != c {
-			break
-		}
-	}
-	return decimal[:i+1]
-}
-
-// trimSpace removes leading and trailing spaces from the given byte slice or string.
-func trimSpace[T bytestr](decimal T) T {
-	return trimRight(trimLeft(decimal, ' '), ' ')
-}
-
-// getSign extracts the sign and the absolute value of a decimal string.
-// - decimal: The input decimal string or byte slice (may include leading/trailing spaces).
-// - Returns:
-//   - sign: An empty string for positive numbers, or a "-" for negative numbers.
-//   - abs: The absolute value of the input (without the sign or leading spaces).
-//
-// If the input is empty or contains only spaces, both sign and abs are empty.
-// Example:
-//
-//	getSign("-123") => "-", "123"
-//	getSign("+123") => "", "123"
-//	getSign("  123") => "", "123"
-//	getSign("   ") => "", ""
-func getSign[T bytestr](decimal T) (sign T, abs T) {
-	abs = trimSpace(decimal)
-	if len(abs) == 0 {
-		return abs, abs
-	}
-	switch abs[0] {
-	case '-': // Negative sign detected; trim it and return.
-		return abs[:1], trimLeft(abs[1:], ' ')
-	case '+': // Positive sign detected; trim it and return.
-		return abs[:0], trimLeft(abs[1:], ' ')
-	default: // No sign detected; return the absolute value.
-		return abs[:0], abs
-	}
-}
-
-// flushAtoB appends the contents of b to a and resets b to an empty slice.
-func flushBtoA(a, b *[]byte) {
-	if len(*b) > 0 {
-		*a = append(*a, *b...)
-		*b = (*b)[:0]
-	}
-}
-
-// compose returns the normalized decimal string from the integer and decimal parts.
-func compose(a, b []byte) []byte {
-	a = trimLeft(a, '0')
-	if len(a) == 0 {
-		a = append(a, '0')
-	}
-	b = trimRight(b, '0')
-	if len(b) == 0 {
-		return a
-	}
-	a = append(a, '.')
-	a = append(a, b...)
-	return a
-}
-
-// detectAndNormalize detects the format of a decimal string and returns a normalized version of it.
-// - decimal: The input decimal string or byte slice to process.
-// - Returns:
-//   - normalized: The normalized decimal string (with grouping separators removed and decimal part normalized).
-//   - df: The detected decimal format (point, grouping, and whether grouping is standard or not).
-//   - ok: A boolean indicating if the detection and normalization succeeded.
-//
-// The function supports various separators, such as ',', '.', '\”, and the midpoint '·'.
-// Whitespace, non-standard grouping, and invalid formats are handled gracefully.
-// Examples:
-//
-//	"1,234.56" -> "1234.56", {Point: '.', Group: ',', Standard: true}, true
-//	"123.45"   -> "123.45", {Point: '.', Group: NoSeparator, Standard: true}, true
-//	"123 45"   -> "", {}, false
-//	""         -> "", {}, false
-func detectAndNormalize[T bytestr](decimal T) (normalized T, df DecimalFormat, ok bool) {
-	// temporary variables
-	var (
-		first        rune // first separator found
-		point, group rune // decimal and grouping separators
-		before       int  // number of digits before the separator
-		mode         int  // 0: unknown, 2: non-standard grouping, 3: standard grouping
-		hasDigit     bool // if we have at least one digit
-	)
-	a := make([]byte, 0, len(decimal)) // the integer part (before the decimal separator)
-	b := make([]byte, 0, len(decimal)) // the decimal part (after the decimal separator)
-	buf := &a                          // the current buffer (a or b)
-	sign, abs := getSign(decimal)
-	*buf = append(*buf, sign...)
-	// loop over the bytes of the string
-	for i := 0; i < len(abs); i++ {
-		// handle digits
-		if '0' <= abs[i] && abs[i] <= '9' {
-			before++
-			hasDigit = true
-			*buf = append(*buf, abs[i])
-			continue
-		}
-
-		// handle the first non-digit character
-		if first == 0 {
-			// we never enter twice in this block
-			switch abs[i] {
-			case ',', '.', '\'':
-				first = rune(abs[i])
-				// is the rist separator a decimal separator necessarily?
-				if before == 0 || before > 3 {
-					point = first
-				}
-				buf = &b // we start the possible decimal part (if not we will copy it back to a)
-			case ' ':
-				if before > 3 {
-					return decimal, df, false
-				}
-				first, group = ' ', ' '
-			case 0xC2:
-				if i+1 >= len(abs) || abs[i+1] != 0xB7 {
-					return decimal, df, false
-				}
-				i++
-				first, point = '·', '·'
-				buf = &b // we start the decimal part
-			default:
-				return decimal, df, false
-			}
-			before = 0
-			continue
-		}
-
-		// no more separator is allowed after the decimal separator
-		if point != 0 {
-			return decimal, df, false
-		}
-
-		// handle the grouping separator
-		if first == rune(abs[i]) {
-			// grouping must match standard or non-standard rules (2 or 3 digits).
-			if (before != 2 && before != 3) || (mode > 0 && before != mode) {
-				return decimal, df, false
-			}
-			group, mode, before = first, before, 0
-			// if we were hesitating between a grouping and a decimal separator
-			flushBtoA(&a, &b)
-			buf = &a
-			continue
-		}
-		// the new separator could be only a decimal separator
-		// so the previous one is necessarily a grouping separator
-		group = first
-
-		// handle the decimal separator
-		if abs[i] == 0xC2 && i+1 < len(abs) && abs[i+1] == 0xB7 {
-			i++
-			point = '·'
-		} else {
-			point = rune(abs[i])
-		}
-		// check if the decimal separator is valid
-		if before != 3 || !isPossible(point, group) {
-			return decimal, df, false
-		}
-
-		// handle ambiguity between grouping and decimal separator,
-		// if we have collected some digits in the decimal part
-		// transfer them to the integer part
-		flushBtoA(&a, &b)
-		// start collecting the decimal part
-		buf = &b
-		before = 0
-	}
-
-	// At this point df is zero, {NoSeparator, NoSeparator, false}.
-	// We have to fill it with the detected values.
-
-	// handle strings with no digits
-	if !hasDigit {
-		return decimal, df, false
-	}
-
-	// handle digits without any separator
-	if first == 0 {
-		df.Standard = true
-		return T(compose(a, b)), df, true
-	}
-
-	// handle digits with decimal separator
-	if point != 0 {
-		df.Point, df.Group, df.Standard = point, group, mode != 2
-		return T(compose(a, b)), df, true
-	}
-
-	// handle digits only with grouping separator
-	if group != 0 {
-		if before != 3 {
-			return decimal, df, false
-		}
-		df.Group, df.Standard = group, mode != 2
-		return T(compose(a, b)), df, true
-	}
-
-	// handle digits with single unknown separator
-	if before == 3 {
-		// we are in the ambiguous case (3 digits before the separator)
-		return decimal, df, false
-	}
-	// the only separator is necessarily a decimal separator
-	df.Point, df.Standard = first, true
-	return T(compose(a, b)), df, true
-}
-
-// DetectFormat detects the decimal format of a string.
-// It returns the detected DecimalFormat and a boolean indicating success.
-// The boolean `ok` is false if the string does not contain a valid decimal format
-// or if the format is ambiguous.
-// If it is impossible to determine whether the grouping is standard or non-standard,
-// it defaults to standard.
-func DetectFormat[T bytestr](decimal T) (df DecimalFormat, ok bool) {
-	_, df, ok = detectAndNormalize(decimal)
-	return df, ok
-}
-
-// Normalize returns a normalized decimal string.
-// A normalized decimal string adheres to the following rules:
-//   - May start with a '-' (negative sign).
-//   - Is followed by one or more digits.
-//   - If a '.' is present, it is followed by one or more digits (e.g., "123." -> "123").
-//   - Cannot start with '0' unless the integer part is exactly 0 (e.g., "0123.4" -> "123.4").
-//   - Cannot have trailing zeros after the '.' (e.g., "123.000" -> "123").
-//   - Cannot have a trailing '.' (e.g., "123." -> "123").
-func Normalize[T bytestr](decimal T) (normalized T) {
-	normalized, _, _ = detectAndNormalize(decimal)
-	return normalized
-}
-
-// NormalizeCheck returns a normalized decimal string and a boolean.
-// The boolean `ok` is true if the input string was successfully normalized;
-// otherwise, it is false, indicating the input string is unchanged.
-func NormalizeCheck[T bytestr](decimal T) (normalized T, ok bool) {
-	normalized, _, ok = detectAndNormalize(decimal)
-	return normalized, ok
-}
-
-// IsNormalized checks if a decimal string is normalized.
-// A normalized decimal string adheres to the following rules:
-//   - May start with a '-' (negative sign).
-//   - Must be followed by one or more digits.
-//   - If a '.' is present, it must be followed by one or more digits.
-//   - Cannot start with '0' unless the integer part is exactly 0.
-//   - Cannot have trailing zeros after the '.' (e.g., "123.000" -> false).
-//   - Cannot have a trailing '.' (e.g., "123." -> false).
-//   - The string cannot be empty.
-func IsNormalized[T bytestr](decimal T) bool {
-	if len(decimal) == 0 {
-		return false
-	}
-	if len(decimal) == 1 && decimal[0] == '0' {
-		return true
-	}
-	var (
-		first     bool // whether we're processing the first character
-		after     bool // whether we're after the '.'
-		c         byte // current character
-		expectDot bool // whether we expect a '.' after a leading '0'
-	)
-	first = true
-	for i := 0; i < len(decimal); i++ {
-		c = decimal[i]
-		// skip leading '-' if any
-		if first && c == '-' {
-			continue
-		}
-		if c == '.' {
-			// '.' cannot be the first character or appear multiple times.
-			if first || after {
-				return false
-			}
-			// we're now processing the decimal part (after the '.')
-			after = true
-			expectDot = false
-			continue
-		}
-		// if we expect a '.' but encounter a digit, it's invalid
-		if c < '0' || c > '9' {
-			return false
-		}
-		// if we expect a '.' but encounter a digit, it's invalid
-		if expectDot {
-			return false
-		}
-		// check if the integer part starts with '0'
-		if first {
-			expectDot = (c == '0')
-		}
-		first = false
-	}
-	// ensure the last character is not '.' or '0' (if we're after '.')
-	if c == '.' || (c == '0' && after) {
-		return false
-	}
-	// special case for '-0'
-	if expectDot {
-		return false
-	}
-	return true
-}
-
-// Convert converts a decimal string to a formatted decimal string using the specified DecimalFormat.
-// If the input string is not a valid decimal string, it returns "0" and false.
-// The input string does not need to be a normalized decimal string.
-// The output string is formatted based on the following rules:
-//   - Grouping separators are inserted every 3 or 2 digits (depending on `df.Standard`).
-//   - A custom decimal separator (`df.Point`) is used.
-//   - Negative numbers retain their '-' sign. If + is present, it is removed.
-func (df DecimalFormat) Convert(decimal string) (new string, ok bool) {
-	// attempt to normalize the decimal string
-	if !IsNormalized(decimal) {
-		decimal = Normalize(decimal)
-		// if normalization fails, return "0" and false
-		if !IsNormalized(decimal) {
-			return "0", false
-		}
-	}
-	// determine the grouping size: 3 for standard formats, 2 for non-standard
-	group := 3
-	if !df.Standard {
-		group = 2
-	}
-
-	// use a strings.Builder for efficient string construction
-	sb := strings.Builder{}
-
-	// handle negative numbers by writing the '-' sign and removing it from the input
-	if decimal[0] == '-' {
-		sb.WriteByte('-')
-		decimal = decimal[1:]
-	}
-
-	// split the string into integer and fractional parts
-	parts := strings.Split(decimal, ".")
-	n := len(parts[0])
-
-	// calculate initial grouping positions
-	k, l := 0, (n-3)%group
-	if l == 0 {
-		l = group
-	}
-
-	// insert grouping separators for the integer part
-	for n > 3 {
-		sb.WriteString(parts[0][k:l])
-		sb.WriteRune(df.Group)
-		k = l
-		l += group
-		n -= group
-	}
-	sb.WriteString(parts[0][k:])
-
-	// append the decimal separator and the fractional part if any
-	if len(parts) == 2 {
-		sb.WriteRune(df.Point)
-		sb.WriteString(parts[1])
-	}
-
-	// return the formatted string and true, indicating success
-	return sb.String(), true
-}
+// decstr is a package for detecting and converting decimal strings.
+// It provides utilities for identifying decimal formats and converting between them.
+package decstr
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NoSeparator represents the absence of a separator and is the 0 rune.
+const NoSeparator = rune(0)
+
+// DecimalFormat describes the format of a decimal string.
+//   - Point: The decimal separator (or NoSeparator if absent).
+//   - Group: The grouping separator (or NoSeparator if absent).
+//   - Standard: True if grouping follows a standard pattern (e.g., groups of 3 digits),
+//     False if it uses a non-standard pattern (e.g., 3 digits then 2 digits).
+type DecimalFormat struct {
+	Point    rune
+	Group    rune
+	Standard bool
+}
+
+// String returns a string representation of the DecimalFormat,
+// formatted as {`<Point>`, `<Group>`, <standard|non-standard>}.
+func (df DecimalFormat) String() string {
+	// sep converts a rune to its string representation or "<none>" if NoSeparator.
+	sep := func(r rune) string {
+		if r == NoSeparator {
+			return "<none>"
+		}
+		return string(r)
+	}
+	std := "non-standard"
+	if df.Standard {
+		std = "standard"
+	}
+	return "{`" + sep(df.Point) + "`, `" + sep(df.Group) + "`, " + std + "}"
+}
+
+// Example returns the number 1234567.89 written in the DecimalFormat, as an illustration
+// for the users, like "1 234 567,89", or "12,34,567.89" for a non-standard grouping.
+// If the format has no decimal separator, the number is 1234567.
+// If the DecimalFormat is not valid, Example returns an empty string.
+// Example:
+//
+//	{`,`, ` `, standard}.Example()     => "1 234 567,89"
+//	{`.`, `,`, non-standard}.Example() => "12,34,567.89"
+func (df DecimalFormat) Example() string {
+	sample := "1234567.89"
+	if df.Point == NoSeparator {
+		sample = "1234567"
+	}
+	example, ok := df.Convert(sample)
+	if !ok {
+		return ""
+	}
+	return example
+}
+
+// possibleGrouping maps each decimal separator to its valid grouping separators.
+// For example, ',' as a decimal separator may use ' ', '.', or '\” as grouping separators.
+// The '_' grouping separator is used by programming languages (see GoLiteral).
+// It is protected by groupingMu as it can be extended by RegisterSeparatorPair.
+var possibleGrouping = map[rune][]rune{
+	',':  {' ', '.', '\''},
+	'.':  {' ', ',', '\'', '_'},
+	'·':  {','},
+	'\'': {'.'},
+}
+
+// groupingMu protects possibleGrouping.
+var groupingMu sync.RWMutex
+
+// IsValidSeparatorPair checks if the given grouping separator is valid for the specified decimal separator.
+func IsValidSeparatorPair(point, group rune) bool {
+	groupingMu.RLock()
+	defer groupingMu.RUnlock()
+	return slices.Contains(possibleGrouping[point], group)
+}
+
+// RegisterSeparatorPair adds a (decimal separator, grouping separator) pair to the valid ones.
+// It returns an error wrapping ErrInvalidFormat if one of the separators is NoSeparator
+// or if both separators are identical. Registering an already valid pair is a no-op.
+// Note that the detection functions only recognize the ',', '.', '\”, ' ' and '·' separators,
+// so registering a pair with other separators only affects IsValidSeparatorPair and Validate
+// (use RegisterFormat to make the detection consider them).
+func RegisterSeparatorPair(point, group rune) error {
+	if point == NoSeparator || group == NoSeparator {
+		return fmt.Errorf("%w: missing separator in pair (%q, %q)", ErrInvalidFormat, point, group)
+	}
+	if point == group {
+		return fmt.Errorf("%w: identical decimal and grouping separators %q", ErrInvalidFormat, point)
+	}
+	groupingMu.Lock()
+	defer groupingMu.Unlock()
+	if !slices.Contains(possibleGrouping[point], group) {
+		possibleGrouping[point] = append(possibleGrouping[point], group)
+	}
+	return nil
+}
+
+// isKnownPoint checks if the given rune is a decimal separator of some valid pair.
+func isKnownPoint(point rune) bool {
+	groupingMu.RLock()
+	defer groupingMu.RUnlock()
+	_, ok := possibleGrouping[point]
+	return ok
+}
+
+// isKnownGroup checks if the given rune is a grouping separator of some valid pair.
+func isKnownGroup(group rune) bool {
+	groupingMu.RLock()
+	defer groupingMu.RUnlock()
+	for _, groups := range possibleGrouping {
+		if slices.Contains(groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListFormats returns all the valid formats (see Validate), including the pairs added
+// by RegisterSeparatorPair, sorted by decimal and grouping separators:
+// the formats without separator, then the ones with a decimal separator only,
+// with both separators in standard and non-standard grouping,
+// and finally the ones with a grouping separator only.
+// They can be presented to the users to choose a target format, like with Example.
+func ListFormats() []DecimalFormat {
+	groupingMu.RLock()
+	points := make([]rune, 0, len(possibleGrouping))
+	var groups []rune
+	pairs := make(map[rune][]rune, len(possibleGrouping))
+	for point, pointGroups := range possibleGrouping {
+		points = append(points, point)
+		pairs[point] = slices.Clone(pointGroups)
+		slices.Sort(pairs[point])
+		for _, group := range pointGroups {
+			if !slices.Contains(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+	}
+	groupingMu.RUnlock()
+	slices.Sort(points)
+	slices.Sort(groups)
+
+	formats := []DecimalFormat{{Point: NoSeparator, Group: NoSeparator, Standard: true}}
+	for _, point := range points {
+		formats = append(formats, DecimalFormat{Point: point, Group: NoSeparator, Standard: true})
+		for _, group := range pairs[point] {
+			formats = append(formats,
+				DecimalFormat{Point: point, Group: group, Standard: true},
+				DecimalFormat{Point: point, Group: group, Standard: false})
+		}
+	}
+	for _, group := range groups {
+		formats = append(formats,
+			DecimalFormat{Point: NoSeparator, Group: group, Standard: true},
+			DecimalFormat{Point: NoSeparator, Group: group, Standard: false})
+	}
+	return formats
+}
+
+// NewDecimalFormat returns a DecimalFormat with the given separators and grouping style.
+// It returns an error if the format is not valid (see Validate).
+func NewDecimalFormat(point, group rune, standard bool) (DecimalFormat, error) {
+	df := DecimalFormat{Point: point, Group: group, Standard: standard}
+	return df, df.Validate()
+}
+
+// Validate checks if the DecimalFormat is one that can be detected, and returns
+// an error wrapping ErrInvalidFormat if it is not. A valid format:
+//   - has different decimal and grouping separators;
+//   - uses a known decimal separator if there is no grouping separator;
+//   - uses a known grouping separator if there is no decimal separator;
+//   - uses a known combination of separators if both are present.
+func (df DecimalFormat) Validate() error {
+	switch {
+	case df.Point == NoSeparator && df.Group == NoSeparator:
+		return nil
+	case df.Point == df.Group:
+		return fmt.Errorf("%w: identical decimal and grouping separators %q", ErrInvalidFormat, df.Point)
+	case df.Group == NoSeparator:
+		if !isKnownPoint(df.Point) {
+			return fmt.Errorf("%w: unknown decimal separator %q", ErrInvalidFormat, df.Point)
+		}
+	case df.Point == NoSeparator:
+		if !isKnownGroup(df.Group) {
+			return fmt.Errorf("%w: unknown grouping separator %q", ErrInvalidFormat, df.Group)
+		}
+	case !IsValidSeparatorPair(df.Point, df.Group):
+		return fmt.Errorf("%w: grouping separator %q is not possible with decimal separator %q", ErrInvalidFormat, df.Group, df.Point)
+	}
+	return nil
+}
+
+// bytestr is a type constraint for []byte and string, used for functions
+// that operate generically on these types.
+type bytestr interface {
+	~[]byte | ~string
+}
+
+// trimLeft removes all leading occurrences of the specified character from the given byte slice or string.
+func trimLeft[T bytestr](decimal T, c byte) T {
+	var i int
+	for i = 0; i < len(decimal); i++ {
+		if decimal[i] != c {
+			break
+		}
+	}
+	return decimal[i:]
+}
+
+// trimRight removes all trailing occurrences of the specified character from the given byte slice or string.
+func trimRight[T bytestr](decimal T, c byte) T {
+	var i int
+	for i = len(decimal) - 1; i >= 0; i-- {
+		if decimal[i] != c {
+			break
+		}
+	}
+	return decimal[:i+1]
+}
+
+// trimSpace removes leading and trailing spaces from the given byte slice or string.
+func trimSpace[T bytestr](decimal T) T {
+	return trimRight(trimLeft(decimal, ' '), ' ')
+}
+
+// getSign extracts the sign and the absolute value of a decimal string.
+// - decimal: The input decimal string or byte slice (may include leading/trailing spaces).
+// - Returns:
+//   - sign: An empty string for positive numbers, or a "-" for negative numbers.
+//   - abs: The absolute value of the input (without the sign or leading spaces).
+//
+// If the input is empty or contains only spaces, both sign and abs are empty.
+// Example:
+//
+//	getSign("-123") => "-", "123"
+//	getSign("+123") => "", "123"
+//	getSign("  123") => "", "123"
+//	getSign("   ") => "", ""
+func getSign[T bytestr](decimal T) (sign T, abs T) {
+	abs = trimSpace(decimal)
+	if len(abs) == 0 {
+		return abs, abs
+	}
+	switch abs[0] {
+	case '-': // Negative sign detected; trim it and return.
+		return abs[:1], trimLeft(abs[1:], ' ')
+	case '+': // Positive sign detected; trim it and return.
+		return abs[:0], trimLeft(abs[1:], ' ')
+	default: // No sign detected; return the absolute value.
+		return abs[:0], abs
+	}
+}
+
+// flushAtoB appends the contents of b to a and resets b to an empty slice.
+func flushBtoA(a, b *[]byte) {
+	if len(*b) > 0 {
+		*a = append(*a, *b...)
+		*b = (*b)[:0]
+	}
+}
+
+// compose returns the normalized decimal string from the integer and decimal parts.
+// The integer part may start with the '-' sign.
+func compose(a, b []byte) []byte {
+	sign := 0
+	if len(a) > 0 && a[0] == '-' {
+		sign = 1
+	}
+	a = append(a[:sign], trimLeft(a[sign:], '0')...)
+	if len(a) == sign {
+		a = append(a, '0')
+	}
+	b = trimRight(b, '0')
+	if len(b) == 0 {
+		if sign == 1 && a[1] == '0' {
+			// the negative zero is the zero
+			return a[1:]
+		}
+		return a
+	}
+	a = append(a, '.')
+	a = append(a, b...)
+	return a
+}
+
+// byte classes used by the scanner of detectAndNormalize
+const (
+	classOther     = iota // any byte that can not start a first separator
+	classDigit            // '0' to '9'
+	classSeparator        // ',', '.' and '\'', decimal or grouping separators
+	classGroup            // ' ' and '_', grouping separators only
+	classMidpoint         // 0xC2, the first byte of the midpoint '·' in UTF-8
+)
+
+// byteClass maps each byte to its class, replacing a chain of comparisons
+// by a single lookup in the hot loop of the scanner. All the other non-ASCII
+// bytes are classOther, so they are rejected as soon as they are seen.
+var byteClass = func() (table [256]uint8) {
+	for c := '0'; c <= '9'; c++ {
+		table[c] = classDigit
+	}
+	table[','], table['.'], table['\''] = classSeparator, classSeparator, classSeparator
+	table[' '], table['_'] = classGroup, classGroup
+	table[0xC2] = classMidpoint
+	return table
+}()
+
+// spaceGroup returns the grouping separator r, replacing the Unicode spaces by ' '.
+func spaceGroup(r rune) rune {
+	if isUnicodeSpace(r) {
+		return ' '
+	}
+	return r
+}
+
+// detectAndNormalize detects the format of a decimal string and returns a normalized version of it.
+// - decimal: The input decimal string or byte slice to process.
+// - Returns:
+//   - normalized: The normalized decimal string (with grouping separators removed and decimal part normalized).
+//   - df: The detected decimal format (point, grouping, and whether grouping is standard or not).
+//   - ok: A boolean indicating if the detection and normalization succeeded.
+//
+// The function supports various separators, such as ',', '.', '\”, the midpoint '·',
+// and the space ' ' or the underscore '_' as grouping separators.
+// Any Unicode space separator (like the no-break space U+00A0 or the thin space U+2009)
+// is a grouping space, reported as ' ' in the format; all the groups must use the same space.
+// Whitespace, non-standard grouping, and invalid formats are handled gracefully.
+// Examples:
+//
+//	"1,234.56" -> "1234.56", {Point: '.', Group: ',', Standard: true}, true
+//	"123.45"   -> "123.45", {Point: '.', Group: NoSeparator, Standard: true}, true
+//	"123 45"   -> "", {}, false
+//	""         -> "", {}, false
+func detectAndNormalize[T bytestr](decimal T) (normalized T, df DecimalFormat, ok bool) {
+	// fast path: already normalized strings are returned as is, without allocation
+	if IsNormalized(decimal) {
+		df, ok = normalizedFormat(decimal)
+		return decimal, df, ok
+	}
+	buf, df, ok, _ := scanDecimal(decimal, make([]byte, 0, len(decimal)), make([]byte, 0, len(decimal)), nil)
+	if !ok {
+		if buf, df, ok = scanCustom(decimal); !ok {
+			return decimal, df, false
+		}
+	}
+	return T(buf), df, true
+}
+
+// scanDecimal does the work of detectAndNormalize using a and b as scratch space
+// for the integer and the decimal parts. The returned normalized decimal uses
+// the memory of a; it is nil if the detection fails, and then at is the index in decimal
+// of the byte where it fails (len(decimal) if the string ends too early).
+// If a is nil, the decimal is only validated: nothing is written and normalized is always nil.
+// If tr is not nil, the decisions are recorded in it (see ExplainDetect).
+func scanDecimal[T bytestr](decimal T, a, b []byte, tr *explainer) (normalized []byte, df DecimalFormat, ok bool, at int) {
+	validate := a == nil
+	// temporary variables
+	var (
+		first        rune // first separator found
+		point, group rune // decimal and grouping separators
+		before       int  // number of digits before the separator
+		last         int  // index of the last separator in decimal
+		mode         int  // 0: unknown, 2: non-standard grouping, 3: standard grouping
+		hasDigit     bool // if we have at least one digit
+	)
+	buf := &a // the current buffer: a for the integer part, b for the decimal part
+	sign, abs := getSign(decimal)
+	if !validate {
+		*buf = append(*buf, sign...)
+	}
+	start := len(trimRight(decimal, ' ')) - len(abs) // the index of abs in decimal
+	// loop over the bytes of the string
+	for i := 0; i < len(abs); i++ {
+		class := byteClass[abs[i]]
+		// handle digits
+		if class == classDigit {
+			before++
+			hasDigit = true
+			if !validate {
+				*buf = append(*buf, abs[i])
+			}
+			continue
+		}
+
+		// the Unicode spaces, like the no-break space, are grouping spaces
+		// (a number must use the same space in all its groups)
+		c, pos := rune(abs[i]), i
+		if c >= utf8.RuneSelf {
+			if r, size := utf8.DecodeRuneInString(string(abs[i:min(len(abs), i+utf8.UTFMax)])); unicode.Is(unicode.Zs, r) {
+				c, class = r, classGroup
+				i += size - 1
+			}
+		}
+
+		// handle the first non-digit character
+		if first == 0 {
+			// we never enter twice in this block
+			switch class {
+			case classSeparator:
+				first, last = c, start+pos
+				// is the rist separator a decimal separator necessarily?
+				if before == 0 || before > 3 {
+					point = first
+				}
+				if tr != nil {
+					tr.firstSeparator(start+pos, before, point != 0)
+				}
+				buf = &b // we start the possible decimal part (if not we will copy it back to a)
+			case classGroup:
+				if before == 0 {
+					if tr != nil {
+						tr.reject(start+pos, "grouping separator without a digit before it")
+					}
+					return nil, df, false, start + pos
+				}
+				if before > 3 {
+					if tr != nil {
+						tr.reject(start+pos, "grouping separator after %s, more than 3", digitCount(before))
+					}
+					return nil, df, false, start + pos
+				}
+				if tr != nil {
+					tr.add(start+pos, "grouping separator (a space or an underscore cannot be a decimal separator)")
+				}
+				first, group, last = c, spaceGroup(c), start+pos
+			case classMidpoint:
+				if i+1 >= len(abs) || abs[i+1] != 0xB7 {
+					if tr != nil {
+						tr.reject(start+i, "not a digit nor a separator")
+					}
+					return nil, df, false, start + i
+				}
+				if tr != nil {
+					tr.add(start+i, "decimal separator (the midpoint is always a decimal separator)")
+				}
+				i++
+				first, point = '·', '·'
+				buf = &b // we start the decimal part
+			default:
+				if tr != nil {
+					tr.reject(start+pos, "not a digit nor a separator")
+				}
+				return nil, df, false, start + pos
+			}
+			before = 0
+			continue
+		}
+
+		// no more separator is allowed after the decimal separator
+		if point != 0 {
+			if tr != nil {
+				tr.afterPoint(start+pos, point)
+			}
+			return nil, df, false, start + pos
+		}
+
+		// handle the grouping separator
+		if first == c {
+			// grouping must match standard or non-standard rules (2 or 3 digits).
+			if (before != 2 && before != 3) || (mode > 0 && before != mode) {
+				if tr != nil {
+					tr.badGroup(start+pos, before, mode)
+				}
+				return nil, df, false, start + pos
+			}
+			if tr != nil {
+				tr.add(start+pos, "repeated separator, so a grouping separator, after a group of %s", digitCount(before))
+			}
+			group, mode, before, last = spaceGroup(first), before, 0, start+pos
+			// if we were hesitating between a grouping and a decimal separator
+			flushBtoA(&a, &b)
+			buf = &a
+			continue
+		}
+		// the new separator could be only a decimal separator
+		// so the previous one is necessarily a grouping separator
+		group = spaceGroup(first)
+
+		// handle the decimal separator
+		at = start + pos
+		if class == classMidpoint && i+1 < len(abs) && abs[i+1] == 0xB7 {
+			i++
+			point = '·'
+		} else {
+			point = c
+		}
+		// check if the decimal separator is valid
+		if before != 3 || !IsValidSeparatorPair(point, group) {
+			if tr != nil {
+				tr.badPoint(at, before, group)
+			}
+			return nil, df, false, at
+		}
+		if tr != nil {
+			tr.add(at, "another separator, so the decimal one, and %q is the grouping separator", group)
+		}
+
+		// handle ambiguity between grouping and decimal separator,
+		// if we have collected some digits in the decimal part
+		// transfer them to the integer part
+		flushBtoA(&a, &b)
+		// start collecting the decimal part
+		buf = &b
+		before = 0
+	}
+
+	// At this point df is zero, {NoSeparator, NoSeparator, false}.
+	// We have to fill it with the detected values.
+
+	// handle strings with no digits
+	end := start + len(abs)
+	if !hasDigit {
+		if tr != nil {
+			tr.rejectEnd(end, "no digit")
+		}
+		return nil, df, false, end
+	}
+
+	switch {
+	case first == 0:
+		// handle digits without any separator
+		df.Standard = true
+	case point != 0:
+		// handle digits with decimal separator
+		df.Point, df.Group, df.Standard = point, group, mode != 2
+	case group != 0:
+		// handle digits only with grouping separator
+		if before != 3 {
+			// the last grouping separator is not followed by 3 digits
+			if tr != nil {
+				tr.rejectEnd(end, "the last group has %s, want 3", digitCount(before))
+			}
+			return nil, df, false, last
+		}
+		df.Group, df.Standard = group, mode != 2
+	case before == 3:
+		// handle digits with single unknown separator:
+		// we are in the ambiguous case (3 digits before the separator)
+		if tr != nil {
+			tr.rejectEnd(end, "ambiguous, %q followed by 3 digits may be a decimal or a grouping separator", first)
+		}
+		return nil, df, false, last
+	default:
+		// the only separator is necessarily a decimal separator
+		if tr != nil {
+			tr.addEnd(end, "the only separator %q is the decimal one, as it is followed by %s (not 3)", first, digitCount(before))
+		}
+		df.Point, df.Standard = first, true
+	}
+	if tr != nil && mode == 2 {
+		tr.addEnd(end, "non-standard grouping, as the groups before the last one have 2 digits")
+	}
+	if validate {
+		return nil, df, true, 0
+	}
+	return compose(a, b), df, true, 0
+}
+
+// normalizedFormat returns the format of an already normalized decimal string.
+// It is false for the strings that the detection considers ambiguous,
+// like "1.234", where the '.' could also be a grouping separator.
+func normalizedFormat[T bytestr](normalized T) (df DecimalFormat, ok bool) {
+	df.Standard = true
+	dot := -1
+	for i := 0; i < len(normalized); i++ {
+		if normalized[i] == '.' {
+			dot = i
+			break
+		}
+	}
+	if dot < 0 {
+		return df, true
+	}
+	before := dot
+	if normalized[0] == '-' {
+		before--
+	}
+	if before <= 3 && len(normalized)-dot-1 == 3 {
+		return DecimalFormat{}, false
+	}
+	df.Point = '.'
+	return df, true
+}
+
+// DetectFormat detects the decimal format of a string.
+// It returns the detected DecimalFormat and a boolean indicating success.
+// The boolean `ok` is false if the string does not contain a valid decimal format
+// or if the format is ambiguous.
+// If it is impossible to determine whether the grouping is standard or non-standard,
+// it defaults to standard.
+func DetectFormat[T bytestr](decimal T) (df DecimalFormat, ok bool) {
+	_, df, ok = detectAndNormalize(decimal)
+	return df, ok
+}
+
+// Normalize returns a normalized decimal string.
+// A normalized decimal string adheres to the following rules:
+//   - May start with a '-' (negative sign).
+//   - Is followed by one or more digits.
+//   - If a '.' is present, it is followed by one or more digits (e.g., "123." -> "123").
+//   - Cannot start with '0' unless the integer part is exactly 0 (e.g., "0123.4" -> "123.4").
+//   - Cannot have trailing zeros after the '.' (e.g., "123.000" -> "123").
+//   - Cannot have a trailing '.' (e.g., "123." -> "123").
+//   - Cannot be a negative zero (e.g., "-0.00" -> "0", see WithNegativeZero to change this in Parse).
+//
+// An already normalized input is returned as is, without any allocation.
+func Normalize[T bytestr](decimal T) (normalized T) {
+	normalized, _, _ = detectAndNormalize(decimal)
+	return normalized
+}
+
+// NormalizeCheck returns a normalized decimal string and a boolean.
+// The boolean `ok` is true if the input string was successfully normalized;
+// otherwise, it is false, indicating the input string is unchanged.
+func NormalizeCheck[T bytestr](decimal T) (normalized T, ok bool) {
+	normalized, _, ok = detectAndNormalize(decimal)
+	return normalized, ok
+}
+
+// IsDecimal checks if the string is a valid and non ambiguous decimal string,
+// i.e. if NormalizeCheck (or DetectFormat) would succeed.
+// It does not build the normalized decimal, and so does not allocate
+// (unless custom formats are registered, see RegisterFormat).
+func IsDecimal[T bytestr](decimal T) bool {
+	if _, _, ok, _ := scanDecimal(decimal, nil, nil, nil); ok {
+		return true
+	}
+	_, _, ok := scanCustom(decimal)
+	return ok
+}
+
+// NormalizeInPlace normalizes the decimal in its own memory, like Normalize, and returns
+// the normalized decimal, that shares the memory of buf, and true.
+// As the normalization only removes bytes or replaces the separators, it does not allocate,
+// except for the decimals without integer digits, like ",5" (normalized to "0.5"),
+// when buf has no spare capacity (see RegisterFormat for the custom formats).
+// If buf is not a valid, non ambiguous, decimal, it is returned unchanged with false.
+func NormalizeInPlace(buf []byte) ([]byte, bool) {
+	if IsNormalized(buf) {
+		// the ambiguous decimals, like "1.234", are not valid
+		_, ok := normalizedFormat(buf)
+		return buf, ok
+	}
+	_, df, ok, _ := scanDecimal(buf, nil, nil, nil)
+	if !ok {
+		if _, df, ok = scanCustom(buf); !ok {
+			return buf, false
+		}
+	}
+	// copy the sign, the digits and the decimal separator (as '.') at the start of buf,
+	// the write position never passes the read one
+	sign, abs := getSign(buf)
+	w := copy(buf, sign)
+	for i := 0; i < len(abs); {
+		if byteClass[abs[i]] == classDigit {
+			buf[w] = abs[i]
+			w, i = w+1, i+1
+			continue
+		}
+		r, size := utf8.DecodeRune(abs[i:])
+		if r == df.Point {
+			buf[w] = '.'
+			w++
+		}
+		i += size
+	}
+	// remove the leading and trailing zeros
+	res := buf[:w]
+	intPart, fracPart, _ := bytes.Cut(res[len(sign):], []byte{'.'})
+	intPart, fracPart = trimLeft(intPart, '0'), trimRight(fracPart, '0')
+	switch {
+	case len(intPart) == 0 && len(fracPart) == 0:
+		// the zero, never negative
+		return append(buf[:0], '0'), true
+	case len(intPart) == 0:
+		// the fraction part is moved to write "0.", and may need one more byte
+		end := len(sign) + 2 + len(fracPart)
+		if end > len(res) {
+			res = append(res, 0)
+		}
+		copy(res[len(sign)+2:], fracPart)
+		res[len(sign)], res[len(sign)+1] = '0', '.'
+		return res[:end], true
+	}
+	n := len(sign) + copy(res[len(sign):], intPart)
+	if len(fracPart) > 0 {
+		res[n] = '.'
+		n += 1 + copy(res[n+1:], fracPart)
+	}
+	return res[:n], true
+}
+
+// IsNormalized checks if a decimal string is normalized.
+// A normalized decimal string adheres to the following rules:
+//   - May start with a '-' (negative sign).
+//   - Must be followed by one or more digits.
+//   - If a '.' is present, it must be followed by one or more digits.
+//   - Cannot start with '0' unless the integer part is exactly 0.
+//   - Cannot have trailing zeros after the '.' (e.g., "123.000" -> false).
+//   - Cannot have a trailing '.' (e.g., "123." -> false).
+//   - The string cannot be empty.
+func IsNormalized[T bytestr](decimal T) bool {
+	if len(decimal) == 0 {
+		return false
+	}
+	if len(decimal) == 1 && decimal[0] == '0' {
+		return true
+	}
+	var (
+		first     bool // whether we're processing the first character
+		after     bool // whether we're after the '.'
+		c         byte // current character
+		expectDot bool // whether we expect a '.' after a leading '0'
+	)
+	first = true
+	for i := 0; i < len(decimal); i++ {
+		c = decimal[i]
+		// skip leading '-' if any
+		if i == 0 && c == '-' {
+			continue
+		}
+		if c == '.' {
+			// '.' cannot be the first character or appear multiple times.
+			if first || after {
+				return false
+			}
+			// we're now processing the decimal part (after the '.')
+			after = true
+			expectDot = false
+			continue
+		}
+		// if we expect a '.' but encounter a digit, it's invalid
+		if c < '0' || c > '9' {
+			return false
+		}
+		// if we expect a '.' but encounter a digit, it's invalid
+		if expectDot {
+			return false
+		}
+		// check if the integer part starts with '0'
+		if first {
+			expectDot = (c == '0')
+		}
+		first = false
+	}
+	// ensure the last character is not '.' or '0' (if we're after '.')
+	if c == '.' || (c == '0' && after) {
+		return false
+	}
+	// special case for '-0', and a lone '-' without digits
+	if expectDot || first {
+		return false
+	}
+	return true
+}
+
+// toNormalized returns the normalized version of a decimal string.
+// Unlike NormalizeCheck, it succeeds on already normalized strings that are
+// ambiguous for the detection, like "1.234".
+func toNormalized[T bytestr](decimal T) (normalized T, ok bool) {
+	if IsNormalized(decimal) {
+		return decimal, true
+	}
+	normalized = Normalize(decimal)
+	return normalized, IsNormalized(normalized)
+}
+
+// Convert converts a decimal string to a formatted decimal string using the specified DecimalFormat.
+// If the input string is not a valid decimal string, it returns "0" and false.
+// The input string does not need to be a normalized decimal string.
+// The output string is formatted based on the following rules:
+//   - Grouping separators are inserted every 3 or 2 digits (depending on `df.Standard`),
+//     unless `df.Group` is NoSeparator.
+//   - A custom decimal separator (`df.Point`) is used.
+//   - Negative numbers retain their '-' sign. If + is present, it is removed.
+//
+// If the DecimalFormat is not valid (see Validate), or if the decimal has a fractional part
+// but `df.Point` is NoSeparator, it returns "0" and false.
+// The options WithSpecialValues and WithSpecialNames enable the conversion of NaN and infinities,
+// WithPlusSign or WithSpaceSign add a sign to the positive numbers, WithMinScale pads the fraction
+// with zeros, WithWidth pads the result to a minimal width, and WithAccounting and
+// WithCurrencySymbol write the accounting style. With WithStrictness(SIStrict) the SI rules
+// are written, and the formats that do not follow them fail (see SIStrict).
+func (df DecimalFormat) Convert(decimal string, opts ...Option) (new string, ok bool) {
+	var o *options
+	if len(opts) > 0 {
+		o = newOptions(opts)
+	}
+	// the SI formats are checked by appendSI
+	if (o == nil || !o.si) && df.Validate() != nil {
+		return "0", false
+	}
+	if o != nil {
+		if special, ok := parseSpecial(decimal); ok && o.specialValues {
+			return o.specialName(special), true
+		}
+	}
+	sep := encodeSeparators(df)
+	return convertString(df, &sep, decimal, o)
+}
+
+// convertString is convert for a string decimal, returning "0" and false if it fails.
+// It formats in a stack buffer when it is large enough, so that the only allocation
+// is the returned string.
+func convertString(df DecimalFormat, sep *separators, decimal string, o *options) (string, bool) {
+	var arr [64]byte
+	buf, normalized, ok := convert(arr[:0], df, sep, decimal, o)
+	if !ok {
+		return "0", false
+	}
+	if buf == nil {
+		return normalized, true
+	}
+	return string(buf), true
+}
+
+// convert converts the decimal to the valid DecimalFormat df, whose encoded separators are sep,
+// with the options o (nil if none), once the special values are handled.
+// It returns the normalized decimal and a nil buf if it is the result itself,
+// and else the result appended to buf. It returns false if the decimal is not valid,
+// or has a fractional part but the format has no decimal separator.
+func convert[T bytestr](buf []byte, df DecimalFormat, sep *separators, decimal T, o *options) (_ []byte, normalized T, ok bool) {
+	normalized, ok = toNormalized(decimal)
+	if !ok {
+		return nil, normalized, false
+	}
+	decorates := o != nil && o.decorates()
+	if decorates {
+		normalized = T(o.prepare(df, string(normalized)))
+	}
+	// nothing to do if the output is identical to the normalized input
+	size := separatedLen(sep, normalized)
+	if size == len(normalized) && (df.Point == '.' || intEnd(normalized) == len(normalized)) && !decorates {
+		return nil, normalized, true
+	}
+	if size > cap(buf)-len(buf) {
+		buf = append(make([]byte, 0, len(buf)+size), buf...)
+	}
+	if o != nil && o.si {
+		buf, ok = appendSI(buf, df, string(normalized))
+	} else {
+		buf, ok = appendSeparated(buf, sep, normalized)
+	}
+	if !ok {
+		return nil, normalized, false
+	}
+	if o != nil {
+		buf = o.layout(buf)
+	}
+	return buf, normalized, true
+}
+
+// ConvertErr is DecimalFormat.Convert reporting the failures with an error instead of
+// returning "0", so that an invalid input cannot become a legitimate-looking zero amount.
+// It returns "" and an error wrapping ErrInvalidFormat if the DecimalFormat is not valid
+// (or does not follow the SI rules with WithStrictness(SIStrict)), or ErrInvalid if the input
+// is not a valid decimal string (or has a fractional part but the format has no decimal separator).
+func (df DecimalFormat) ConvertErr(decimal string, opts ...Option) (string, error) {
+	var err error
+	if len(opts) > 0 && newOptions(opts).si {
+		err = validateSI(df)
+	} else {
+		err = df.Validate()
+	}
+	if err != nil {
+		return "", err
+	}
+	converted, ok := df.Convert(decimal, opts...)
+	if ok {
+		return converted, nil
+	}
+	if normalized, ok := toNormalized(decimal); ok && df.Point == NoSeparator && strings.Contains(normalized, ".") {
+		return "", fmt.Errorf("%w: %q has a fractional part but the format %v has no decimal separator", ErrInvalid, decimal, df)
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalid, decimal)
+}
+
+// Convert is the generic version of the DecimalFormat.Convert method:
+// a []byte input produces a []byte output without intermediate string conversions.
+// (A method cannot have type parameters, hence this function.)
+func Convert[T bytestr](df DecimalFormat, decimal T, opts ...Option) (new T, ok bool) {
+	var o *options
+	if len(opts) > 0 {
+		o = newOptions(opts)
+	}
+	// the SI formats are checked by appendSI
+	if (o == nil || !o.si) && df.Validate() != nil {
+		return T("0"), false
+	}
+	if o != nil {
+		if special, ok := parseSpecial(decimal); ok && o.specialValues {
+			return T(o.specialName(special)), true
+		}
+	}
+	sep := encodeSeparators(df)
+	buf, normalized, ok := convert(nil, df, &sep, decimal, o)
+	if !ok {
+		return T("0"), false
+	}
+	if buf == nil {
+		// a copy, as the normalized []byte may be the input itself
+		buf = append([]byte(nil), normalized...)
+	}
+	return T(buf), true
+}
@@ -1,402 +1,821 @@
-package decstr
-
-import (
-	"fmt"
-	"testing"
-)
-
-func TestDecimalFormatString(t *testing.T) {
-	tests := []struct {
-		df   DecimalFormat
-		want string
-	}{
-		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "{`.`, `<none>`, standard}"},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "{`.`, ` `, standard}"},
-		{DecimalFormat{Point: ',', Group: '\'', Standard: false}, "{`,`, `'`, non-standard}"},
-		{DecimalFormat{Point: '·', Group: NoSeparator, Standard: false}, "{`·`, `<none>`, non-standard}"},
-	}
-
-	for _, test := range tests {
-		got := test.df.String()
-		if got != test.want {
-			t.Errorf("(%v).String() = %q, want %q", test.df, got, test.want)
-		}
-	}
-}
-
-func TestGetSign(t *testing.T) {
-	testStrings := []struct {
-		decimal string
-		sign    string
-		abs     string
-	}{
-		{"", "", ""},
-		{"  ", "", ""},
-		{"0", "", "0"},
-		{" 0", "", "0"},
-		{"0 ", "", "0"},
-		{"+1", "", "1"},
-		{"+ 123", "", "123"},
-		{"-1", "-", "1"},
-		{"  -   123  ", "-", "123"},
-	}
-
-	testBytes := []struct {
-		decimal []byte
-		sign    []byte
-		abs     []byte
-	}{
-		{[]byte(""), []byte(""), []byte("")},
-		{[]byte("  "), []byte(""), []byte("")},
-		{[]byte("0"), []byte(""), []byte("0")},
-		{[]byte(" 0"), []byte(""), []byte("0")},
-		{[]byte("0 "), []byte(""), []byte("0")},
-		{[]byte("+1"), []byte(""), []byte("1")},
-		{[]byte("+ 123"), []byte(""), []byte("123")},
-		{[]byte("-1"), []byte("-"), []byte("1")},
-		{[]byte("  -   123  "), []byte("-"), []byte("123")},
-	}
-
-	for _, test := range testStrings {
-		sign, abs := getSign(test.decimal)
-		if sign != test.sign || abs != test.abs {
-			t.Errorf("GetSign(%q) = (%q, %q), want (%q, %q)", test.decimal, sign, abs, test.sign, test.abs)
-		}
-	}
-
-	for _, test := range testBytes {
-		sign, abs := getSign(test.decimal)
-		if string(sign) != string(test.sign) || string(abs) != string(test.abs) {
-			t.Errorf("GetSign(%q) = (%q, %q), want (%q, %q)", test.decimal, sign, abs, test.sign, test.abs)
-		}
-	}
-}
-
-func TestDetectFormat(t *testing.T) {
-	tests := []struct {
-		decimal string
-		df      DecimalFormat
-		ok      bool
-	}{
-		{"", DecimalFormat{}, false},
-		{"  ", DecimalFormat{}, false},
-		{"123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, true},
-		{"1 234", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
-		{"1,234", DecimalFormat{}, false}, // ambiguous
-		{"1.234", DecimalFormat{}, false}, // ambiguous
-		{"1'234", DecimalFormat{}, false}, // ambiguous
-		{"1·234", DecimalFormat{Point: '·', Group: NoSeparator, Standard: true}, true},
-		{"1 234.56", DecimalFormat{Point: '.', Group: ' ', Standard: true}, true},
-		{"1,234.56", DecimalFormat{Point: '.', Group: ',', Standard: true}, true},
-		{"1'234.56", DecimalFormat{Point: '.', Group: '\'', Standard: true}, true},
-		{"1·234.56", DecimalFormat{}, false},
-		{"1 234,56", DecimalFormat{Point: ',', Group: ' ', Standard: true}, true},
-		{"1.234,56", DecimalFormat{Point: ',', Group: '.', Standard: true}, true},
-		{"1'234,56", DecimalFormat{Point: ',', Group: '\'', Standard: true}, true},
-		{"1·234,56", DecimalFormat{}, false},
-		{"1.234'56", DecimalFormat{Point: '\'', Group: '.', Standard: true}, true},
-		{"1·234'56", DecimalFormat{}, false},
-		{"1,234'56", DecimalFormat{}, false},
-		{"1 234'56", DecimalFormat{}, false},
-		{"1,234·56", DecimalFormat{Point: '·', Group: ',', Standard: true}, true},
-		{"1 234·56", DecimalFormat{}, false},
-		{"1'234·56", DecimalFormat{}, false},
-		{"1.234·56", DecimalFormat{}, false},
-		{"1'234'56", DecimalFormat{}, false},
-		{"1'234'567", DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, true},
-		{"1'34'567", DecimalFormat{Point: NoSeparator, Group: '\'', Standard: false}, true},
-		{"1 234 56", DecimalFormat{}, false},
-		{"1 234 567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
-		{"1 34 567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false}, true},
-		{"1 234 567.8", DecimalFormat{Point: '.', Group: ' ', Standard: true}, true},
-		{"1 34 567.8", DecimalFormat{Point: '.', Group: ' ', Standard: false}, true},
-		{".12", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
-		{"12.", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
-		{"12.345 678", DecimalFormat{}, false},
-		{"12¸345", DecimalFormat{}, false},
-		{"1234 567,8", DecimalFormat{}, false},
-		{"1'234 567,8", DecimalFormat{}, false},
-		{"1'2345'678", DecimalFormat{}, false},
-		{"1'23'678'901", DecimalFormat{}, false},
-	}
-
-	for _, test := range tests {
-		df, ok := DetectFormat(test.decimal)
-		if df != test.df || ok != test.ok {
-			t.Errorf("DetectFormat(%q) = (%v, %v), want (%v, %v)", test.decimal, df, ok, test.df, test.ok)
-		}
-	}
-}
-
-func ExampleDetectFormat() {
-	df, ok := DetectFormat("1 234,56")
-	if !ok {
-		fmt.Println("not a decimal")
-	}
-	fmt.Println(df)
-	// Output: {`,`, ` `, standard}
-}
-
-func TestNormalize(t *testing.T) {
-	tests := []struct {
-		decimal string
-		want    string
-	}{
-		{"123", "123"},
-		{"1 234", "1234"},
-		{"1·234", "1.234"},
-		{"1 234.56", "1234.56"},
-		{"1,234.56", "1234.56"},
-		{"1'234.56", "1234.56"},
-		{"1 234,56", "1234.56"},
-		{"1.234,56", "1234.56"},
-		{"1'234,56", "1234.56"},
-		{"1.234'56", "1234.56"},
-		{"1,234·56", "1234.56"},
-		{"1'234'567", "1234567"},
-		{"1'34'567", "134567"},
-		{"1 234 567", "1234567"},
-		{"1 34 567", "134567"},
-		{"1 234 567.8", "1234567.8"},
-		{"1 34 567.8", "134567.8"},
-		{".12", "0.12"},
-		{"12.", "12"},
-		{"012.", "12"},
-		{"012.3", "12.3"},
-		{"12.0", "12"},
-		{"12.30", "12.3"},
-		{"1,234", "1,234"},           // ambiguous
-		{"1.234", "1.234"},           // ambiguous
-		{"1'234", "1'234"},           // ambiguous
-		{"", ""},                     // not a decimal
-		{"  ", "  "},                 // not a decimal
-		{" test ", " test "},         // not a decimal
-		{",", ","},                   // not a decimal
-		{"-,", "-,"},                 // not a decimal
-		{".", "."},                   // not a decimal
-		{"-.", "-."},                 // not a decimal
-		{"+.", "+."},                 // not a decimal
-		{" - .", " - ."},             // not a decimal
-		{"1·234.56", "1·234.56"},     // not a decimal
-		{"1·234,56", "1·234,56"},     // not a decimal
-		{"1·234'56", "1·234'56"},     // not a decimal
-		{"1,234'56", "1,234'56"},     // not a decimal
-		{"1 234'56", "1 234'56"},     // not a decimal
-		{"1 234·56", "1 234·56"},     // not a decimal
-		{"1'234·56", "1'234·56"},     // not a decimal
-		{"1.234·56", "1.234·56"},     // not a decimal
-		{"1'234'56", "1'234'56"},     // not a decimal
-		{"1 234 56", "1 234 56"},     // not a decimal
-		{"12.345 678", "12.345 678"}, // not a decimal
-	}
-
-	for _, test := range tests {
-		got := Normalize(test.decimal)
-		if got != test.want {
-			t.Errorf("Normalize(%q) = %q, want %q", test.decimal, got, test.want)
-		}
-		_, ok := DetectFormat(test.decimal)
-		// if it was a decimal but the result is not normalized
-		if ok && !IsNormalized(got) {
-			t.Errorf("Normalize(%q) = %q is not normalized", test.decimal, got)
-		}
-	}
-}
-
-// BenchmarkNormalize compare Normalize and AutoNormalize functions
-func BenchmarkNormalizeString(b *testing.B) {
-	for i := 0; i < b.N; i++ {
-		Normalize("1 234,50")
-	}
-}
-
-func BenchmarkNormalizeSlice(b *testing.B) {
-	buf := []byte("1 234,50")
-	for i := 0; i < b.N; i++ {
-		Normalize(string(buf))
-	}
-}
-
-func ExampleNormalize() {
-	fmt.Println(Normalize(" - 1 234,50 "))
-	fmt.Println(Normalize("12 345."))
-	// Output:
-	// -1234.5
-	// 12345
-}
-
-func TestNormalizeCheck(t *testing.T) {
-	data := []struct {
-		decimal string
-		want    string
-		ok      bool
-	}{
-		{"123", "123", true},
-		{"1 234", "1234", true},
-		{"1·234", "1.234", true},
-		{"1 234.56", "1234.56", true},
-		{"1,234.56", "1234.56", true},
-		{"1'234.56", "1234.56", true},
-		{"1 234,56", "1234.56", true},
-		{"1.234,56", "1234.56", true},
-		{"1'234,56", "1234.56", true},
-		{"1.234'56", "1234.56", true},
-		{"1,234·56", "1234.56", true},
-		{"1'234'567", "1234567", true},
-		{"1'34'567", "134567", true},
-		{"1 234 567", "1234567", true},
-		{"1 34 567", "134567", true},
-		{"1 234 567.8", "1234567.8", true},
-		{"1 34 567.8", "134567.8", true},
-		{".12", "0.12", true},
-		{"12.", "12", true},
-		{"012.", "12", true},
-		{"012.3", "12.3", true},
-		{"12.0", "12", true},
-		{"12.30", "12.3", true},
-		{"1,234", "1,234", false},           // ambiguous
-		{"1.234", "1.234", false},           // ambiguous
-		{"1'234", "1'234", false},           // ambiguous
-		{"", "", false},                     // not a decimal
-		{"  ", "  ", false},                 // not a decimal
-		{" test ", " test ", false},         // not a decimal
-		{",", ",", false},                   // not a decimal
-		{"-,", "-,", false},                 // not a decimal
-		{".", ".", false},                   // not a decimal
-		{"-.", "-.", false},                 // not a decimal
-		{"+.", "+.", false},                 // not a decimal
-		{" - .", " - .", false},             // not a decimal
-		{"1·234.56", "1·234.56", false},     // not a decimal
-		{"1·234,56", "1·234,56", false},     // not a decimal
-		{"1·234'56", "1·234'56", false},     // not a decimal
-		{"1,234'56", "1,234'56", false},     // not a decimal
-		{"1 234'56", "1 234'56", false},     // not a decimal
-		{"1 234·56", "1 234·56", false},     // not a decimal
-		{"1'234·56", "1'234·56", false},     // not a decimal
-		{"1.234·56", "1.234·56", false},     // not a decimal
-		{"1'234'56", "1'234'56", false},     // not a decimal
-		{"1 234 56", "1 234 56", false},     // not a decimal
-		{"12.345 678", "12.345 678", false}, // not a decimal
-	}
-
-	for _, test := range data {
-		got, ok := NormalizeCheck(test.decimal)
-		if got != test.want || ok != test.ok {
-			t.Errorf("NormalizeCheck(%q) = (%q, %v), want (%q, %v)", test.decimal, got, ok, test.want, test.ok)
-		}
-	}
-}
-
-func TestIsNormalized(t *testing.T) {
-	data := []struct {
-		decimal string
-		want    bool
-	}{
-		{"0", true},
-		{"1230", true},
-		{"-123", true},
-		{"0.1", true},
-		{"-0.1", true},
-		{"123.45", true},
-		{"-123.45", true},
-		{"-0", false},       // not standard 0
-		{"", false},         // not a decimal
-		{"a", false},        // not a decimal
-		{"0123", false},     // starts with 0
-		{"-0123", false},    // starts with 0
-		{".", false},        // starts with '.'
-		{".12", false},      // starts with '.'
-		{"0.", false},       // trailing '.'
-		{"-0.", false},      // trailing '.'
-		{"123.", false},     // trailing '.'
-		{"-123.", false},    // trailing '.'
-		{"0.0", false},      // trailing '0'
-		{"0.10", false},     // trailing '0'
-		{"1 234", false},    // hase group separator
-		{"1·234", false},    // hase '·' character
-		{"1 234.56", false}, // hase space
-		{" 1234.56", false}, // hase space
-		{"1234.56 ", false}, // hase space
-	}
-
-	for _, test := range data {
-		got := IsNormalized(test.decimal)
-		if got != test.want {
-			t.Errorf("IsNormalized(%q) = %v, want %v", test.decimal, got, test.want)
-		}
-	}
-}
-
-func ExampleIsNormalized() {
-	fmt.Println(IsNormalized("-123.45"))
-	fmt.Println(IsNormalized("1 234.5"))
-	// Output:
-	// true
-	// false
-}
-
-func TestConvert(t *testing.T) {
-	data := []struct {
-		df      DecimalFormat
-		decimal string
-		want    string
-		ok      bool
-	}{
-		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "123", "123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "+ 1234", "1 234", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "123456789", "123 456 789", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "123456789", "12 34 56 789", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "-23456789", "-2 34 56 789", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "123456789.123", "123 456 789.123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "123456789.123", "12 34 56 789.123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "- 23456789.123", "-2 34 56 789.123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "+123.456.789,123", "123 456 789.123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "12 34 56 789,123", "12 34 56 789.123", true},
-		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, " - 23 456 789,123", "-2 34 56 789.123", true},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " - 23 456 789,123", "-2,34,56,789·123", true},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, "", "0", false},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " ", "0", false},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " . ", "0", false},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " -. ", "0", false},
-		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " - 123 45 6789,123", "0", false},
-	}
-
-	for _, test := range data {
-		got, ok := test.df.Convert(test.decimal)
-		if got != test.want || ok != test.ok {
-			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, test.want, test.ok)
-		}
-	}
-}
-
-func ExampleDecimalFormat_Convert() {
-	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
-	new, ok := df.Convert("123456789.123")
-	if !ok {
-		fmt.Println("not a decimal")
-	}
-	fmt.Println(new)
-	// Output: 123 456 789,123
-}
-
-// Example demonstrates general usage of the decstr package, including
-// normalization, format detection, and conversion of decimal strings.
-func Example() {
-	decimal := "1'234'567,89"
-
-	// Normalize example
-	normalized := Normalize(decimal)
-	fmt.Println("Normalized:", normalized)
-
-	// Detect format example
-	format, ok := DetectFormat(decimal)
-	fmt.Println("Detected format:", format, "ok:", ok)
-	// Convert example
-	df := DecimalFormat{Point: '.', Group: ' ', Standard: false}
-	converted, ok := df.Convert(decimal)
-	fmt.Println("Converted:", converted, "ok:", ok)
-	// Output:
-	// Normalized: 1234567.89
-	// Detected format: {`,`, `'`, standard} ok: true
-	// Converted: 12 34 567.89 ok: true
-}
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecimalFormatString(t *testing.T) {
+	tests := []struct {
+		df   DecimalFormat
+		want string
+	}{
+		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "{`.`, `<none>`, standard}"},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "{`.`, ` `, standard}"},
+		{DecimalFormat{Point: ',', Group: '\'', Standard: false}, "{`,`, `'`, non-standard}"},
+		{DecimalFormat{Point: '·', Group: NoSeparator, Standard: false}, "{`·`, `<none>`, non-standard}"},
+	}
+
+	for _, test := range tests {
+		got := test.df.String()
+		if got != test.want {
+			t.Errorf("(%v).String() = %q, want %q", test.df, got, test.want)
+		}
+	}
+}
+
+func TestDecimalFormatExample(t *testing.T) {
+	tests := []struct {
+		df   DecimalFormat
+		want string
+	}{
+		{DecimalFormat{Point: ',', Group: ' ', Standard: true}, "1 234 567,89"},
+		{DecimalFormat{Point: '.', Group: ',', Standard: true}, "1,234,567.89"},
+		{DecimalFormat{Point: '.', Group: ',', Standard: false}, "12,34,567.89"},
+		{DecimalFormat{Point: '·', Group: ',', Standard: true}, "1,234,567·89"},
+		{DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, "1234567,89"},
+		{DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, "1'234'567"},
+		{DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, "1234567"},
+		{DecimalFormat{Point: ',', Group: ',', Standard: true}, ""},
+	}
+
+	for _, test := range tests {
+		if got := test.df.Example(); got != test.want {
+			t.Errorf("(%v).Example() = %q, want %q", test.df, got, test.want)
+		}
+	}
+}
+
+func ExampleDecimalFormat_Example() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	fmt.Printf("enter numbers like %s\n", df.Example())
+	// Output: enter numbers like 1.234.567,89
+}
+
+func TestListFormats(t *testing.T) {
+	formats := ListFormats()
+	seen := map[DecimalFormat]bool{}
+	for _, df := range formats {
+		if err := df.Validate(); err != nil {
+			t.Errorf("ListFormats() contains %v: %v", df, err)
+		}
+		if seen[df] {
+			t.Errorf("ListFormats() contains %v twice", df)
+		}
+		seen[df] = true
+		// the example in each format with built-in separators is detected back
+		// (the pairs added by RegisterSeparatorPair in other tests are not detected)
+		builtin := strings.Trim(df.Example(), "0123456789 ,.'·_") == ""
+		if got, _, err := Parse(df.Example()); builtin && (err != nil || !strings.HasPrefix("1234567.89", got)) {
+			t.Errorf("Parse(%q) = (%q, %v), want the example number", df.Example(), got, err)
+		}
+	}
+	for _, df := range []DecimalFormat{
+		{Point: NoSeparator, Group: NoSeparator, Standard: true},
+		{Point: ',', Group: NoSeparator, Standard: true},
+		{Point: ',', Group: ' ', Standard: true},
+		{Point: '.', Group: ',', Standard: false},
+		{Point: '·', Group: ',', Standard: true},
+		{Point: NoSeparator, Group: '_', Standard: true},
+	} {
+		if !seen[df] {
+			t.Errorf("ListFormats() does not contain %v", df)
+		}
+	}
+	if formats[0] != (DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}) {
+		t.Errorf("ListFormats()[0] = %v, want the format without separators", formats[0])
+	}
+}
+
+func TestGetSign(t *testing.T) {
+	testStrings := []struct {
+		decimal string
+		sign    string
+		abs     string
+	}{
+		{"", "", ""},
+		{"  ", "", ""},
+		{"0", "", "0"},
+		{" 0", "", "0"},
+		{"0 ", "", "0"},
+		{"+1", "", "1"},
+		{"+ 123", "", "123"},
+		{"-1", "-", "1"},
+		{"  -   123  ", "-", "123"},
+	}
+
+	testBytes := []struct {
+		decimal []byte
+		sign    []byte
+		abs     []byte
+	}{
+		{[]byte(""), []byte(""), []byte("")},
+		{[]byte("  "), []byte(""), []byte("")},
+		{[]byte("0"), []byte(""), []byte("0")},
+		{[]byte(" 0"), []byte(""), []byte("0")},
+		{[]byte("0 "), []byte(""), []byte("0")},
+		{[]byte("+1"), []byte(""), []byte("1")},
+		{[]byte("+ 123"), []byte(""), []byte("123")},
+		{[]byte("-1"), []byte("-"), []byte("1")},
+		{[]byte("  -   123  "), []byte("-"), []byte("123")},
+	}
+
+	for _, test := range testStrings {
+		sign, abs := getSign(test.decimal)
+		if sign != test.sign || abs != test.abs {
+			t.Errorf("GetSign(%q) = (%q, %q), want (%q, %q)", test.decimal, sign, abs, test.sign, test.abs)
+		}
+	}
+
+	for _, test := range testBytes {
+		sign, abs := getSign(test.decimal)
+		if string(sign) != string(test.sign) || string(abs) != string(test.abs) {
+			t.Errorf("GetSign(%q) = (%q, %q), want (%q, %q)", test.decimal, sign, abs, test.sign, test.abs)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		decimal string
+		df      DecimalFormat
+		ok      bool
+	}{
+		{"", DecimalFormat{}, false},
+		{"  ", DecimalFormat{}, false},
+		{"123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, true},
+		{"1 234", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
+		{"1,234", DecimalFormat{}, false}, // ambiguous
+		{"1.234", DecimalFormat{}, false}, // ambiguous
+		{"1'234", DecimalFormat{}, false}, // ambiguous
+		{"1·234", DecimalFormat{Point: '·', Group: NoSeparator, Standard: true}, true},
+		{"1 234.56", DecimalFormat{Point: '.', Group: ' ', Standard: true}, true},
+		{"1,234.56", DecimalFormat{Point: '.', Group: ',', Standard: true}, true},
+		{"1'234.56", DecimalFormat{Point: '.', Group: '\'', Standard: true}, true},
+		{"1·234.56", DecimalFormat{}, false},
+		{"1 234,56", DecimalFormat{Point: ',', Group: ' ', Standard: true}, true},
+		{"1.234,56", DecimalFormat{Point: ',', Group: '.', Standard: true}, true},
+		{"1'234,56", DecimalFormat{Point: ',', Group: '\'', Standard: true}, true},
+		{"1·234,56", DecimalFormat{}, false},
+		{"1.234'56", DecimalFormat{Point: '\'', Group: '.', Standard: true}, true},
+		{"1·234'56", DecimalFormat{}, false},
+		{"1,234'56", DecimalFormat{}, false},
+		{"1\u00A0234,56", DecimalFormat{Point: ',', Group: ' ', Standard: true}, true},
+		{"1\u202F234\u202F567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
+		{"1\u2009234.5", DecimalFormat{Point: '.', Group: ' ', Standard: true}, true},
+		{"1\u3000234", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
+		{"1\u00A034\u00A0567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false}, true},
+		{"1 234\u00A0567", DecimalFormat{}, false}, // different spaces
+		{"1\u00A0234 567", DecimalFormat{}, false}, // different spaces
+		{"1\u2028234", DecimalFormat{}, false},     // a line separator is not a space separator
+		{"\u00A0199", DecimalFormat{}, false},
+		{"\u2009123", DecimalFormat{}, false},
+		{"1 234'56", DecimalFormat{}, false},
+		{"1,234·56", DecimalFormat{Point: '·', Group: ',', Standard: true}, true},
+		{"1 234·56", DecimalFormat{}, false},
+		{"1'234·56", DecimalFormat{}, false},
+		{"1.234·56", DecimalFormat{}, false},
+		{"1'234'56", DecimalFormat{}, false},
+		{"1'234'567", DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, true},
+		{"1'34'567", DecimalFormat{Point: NoSeparator, Group: '\'', Standard: false}, true},
+		{"1 234 56", DecimalFormat{}, false},
+		{"1 234 567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, true},
+		{"1 34 567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false}, true},
+		{"1 234 567.8", DecimalFormat{Point: '.', Group: ' ', Standard: true}, true},
+		{"1 34 567.8", DecimalFormat{Point: '.', Group: ' ', Standard: false}, true},
+		{".12", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
+		{"12.", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
+		{"12.345 678", DecimalFormat{}, false},
+		{"12¸345", DecimalFormat{}, false},
+		{"1234 567,8", DecimalFormat{}, false},
+		{"1'234 567,8", DecimalFormat{}, false},
+		{"1'2345'678", DecimalFormat{}, false},
+		{"1'23'678'901", DecimalFormat{}, false},
+		{"1_234_567.8", DecimalFormat{Point: '.', Group: '_', Standard: true}, true},
+		{"1_234", DecimalFormat{Point: NoSeparator, Group: '_', Standard: true}, true},
+		{"1_234,5", DecimalFormat{}, false},
+		{"_199", DecimalFormat{}, false},
+		{"_002", DecimalFormat{}, false},
+		{"1234_567", DecimalFormat{}, false},
+	}
+
+	for _, test := range tests {
+		df, ok := DetectFormat(test.decimal)
+		if df != test.df || ok != test.ok {
+			t.Errorf("DetectFormat(%q) = (%v, %v), want (%v, %v)", test.decimal, df, ok, test.df, test.ok)
+		}
+	}
+}
+
+func ExampleDetectFormat() {
+	df, ok := DetectFormat("1 234,56")
+	if !ok {
+		fmt.Println("not a decimal")
+	}
+	fmt.Println(df)
+	// Output: {`,`, ` `, standard}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    string
+	}{
+		{"123", "123"},
+		{"1 234", "1234"},
+		{"1·234", "1.234"},
+		{"1 234.56", "1234.56"},
+		{"1,234.56", "1234.56"},
+		{"1'234.56", "1234.56"},
+		{"1 234,56", "1234.56"},
+		{"1.234,56", "1234.56"},
+		{"1'234,56", "1234.56"},
+		{"1.234'56", "1234.56"},
+		{"1,234·56", "1234.56"},
+		{"1'234'567", "1234567"},
+		{"1'34'567", "134567"},
+		{"1 234 567", "1234567"},
+		{"1 34 567", "134567"},
+		{"1 234 567.8", "1234567.8"},
+		{"1 34 567.8", "134567.8"},
+		{".12", "0.12"},
+		{"12.", "12"},
+		{"012.", "12"},
+		{"012.3", "12.3"},
+		{"12.0", "12"},
+		{"12.30", "12.3"},
+		{"-0012.30", "-12.3"},
+		{"-.5", "-0.5"},
+		{"-0", "0"},
+		{"-0,00", "0"},
+		{" - 000", "0"},
+		{"- 012 345", "-12345"},
+		{"1,234", "1,234"},           // ambiguous
+		{"1.234", "1.234"},           // ambiguous
+		{"1'234", "1'234"},           // ambiguous
+		{"", ""},                     // not a decimal
+		{"  ", "  "},                 // not a decimal
+		{" test ", " test "},         // not a decimal
+		{",", ","},                   // not a decimal
+		{"-,", "-,"},                 // not a decimal
+		{".", "."},                   // not a decimal
+		{"-.", "-."},                 // not a decimal
+		{"+.", "+."},                 // not a decimal
+		{" - .", " - ."},             // not a decimal
+		{"1·234.56", "1·234.56"},     // not a decimal
+		{"1·234,56", "1·234,56"},     // not a decimal
+		{"1·234'56", "1·234'56"},     // not a decimal
+		{"1,234'56", "1,234'56"},     // not a decimal
+		{"1 234'56", "1 234'56"},     // not a decimal
+		{"1 234·56", "1 234·56"},     // not a decimal
+		{"1'234·56", "1'234·56"},     // not a decimal
+		{"1.234·56", "1.234·56"},     // not a decimal
+		{"1'234'56", "1'234'56"},     // not a decimal
+		{"1 234 56", "1 234 56"},     // not a decimal
+		{"12.345 678", "12.345 678"}, // not a decimal
+	}
+
+	for _, test := range tests {
+		got := Normalize(test.decimal)
+		if got != test.want {
+			t.Errorf("Normalize(%q) = %q, want %q", test.decimal, got, test.want)
+		}
+		_, ok := DetectFormat(test.decimal)
+		// if it was a decimal but the result is not normalized
+		if ok && !IsNormalized(got) {
+			t.Errorf("Normalize(%q) = %q is not normalized", test.decimal, got)
+		}
+	}
+}
+
+// BenchmarkNormalize compare Normalize and AutoNormalize functions
+func BenchmarkNormalizeString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Normalize("1 234,50")
+	}
+}
+
+func BenchmarkNormalizeSlice(b *testing.B) {
+	buf := []byte("1 234,50")
+	for i := 0; i < b.N; i++ {
+		Normalize(string(buf))
+	}
+}
+
+func TestByteClass(t *testing.T) {
+	for c := 0; c < 256; c++ {
+		want := classOther
+		switch {
+		case '0' <= c && c <= '9':
+			want = classDigit
+		case c == ',' || c == '.' || c == '\'':
+			want = classSeparator
+		case c == ' ' || c == '_':
+			want = classGroup
+		case c == 0xC2:
+			want = classMidpoint
+		}
+		if got := byteClass[c]; int(got) != want {
+			t.Errorf("byteClass[%q] = %d, want %d", c, got, want)
+		}
+	}
+}
+
+func TestNormalizeFastPathAllocs(t *testing.T) {
+	decimal := []byte("-1234567.891")
+	allocs := testing.AllocsPerRun(100, func() {
+		Normalize(decimal)
+	})
+	if allocs != 0 {
+		t.Errorf("Normalize of a normalized input allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkNormalizeNormalized(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Normalize("-1234567.891")
+	}
+}
+
+func ExampleNormalize() {
+	fmt.Println(Normalize(" - 1 234,50 "))
+	fmt.Println(Normalize("12 345."))
+	// Output:
+	// -1234.5
+	// 12345
+}
+
+func TestNormalizeCheck(t *testing.T) {
+	data := []struct {
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{"123", "123", true},
+		{"1 234", "1234", true},
+		{"1·234", "1.234", true},
+		{"1 234.56", "1234.56", true},
+		{"1,234.56", "1234.56", true},
+		{"1'234.56", "1234.56", true},
+		{"1 234,56", "1234.56", true},
+		{"1.234,56", "1234.56", true},
+		{"1'234,56", "1234.56", true},
+		{"1.234'56", "1234.56", true},
+		{"1,234·56", "1234.56", true},
+		{"1'234'567", "1234567", true},
+		{"1'34'567", "134567", true},
+		{"1 234 567", "1234567", true},
+		{"1 34 567", "134567", true},
+		{"1 234 567.8", "1234567.8", true},
+		{"1 34 567.8", "134567.8", true},
+		{".12", "0.12", true},
+		{"12.", "12", true},
+		{"012.", "12", true},
+		{"012.3", "12.3", true},
+		{"12.0", "12", true},
+		{"12.30", "12.3", true},
+		{"1,234", "1,234", false},           // ambiguous
+		{"1.234", "1.234", false},           // ambiguous
+		{"1'234", "1'234", false},           // ambiguous
+		{"", "", false},                     // not a decimal
+		{"  ", "  ", false},                 // not a decimal
+		{" test ", " test ", false},         // not a decimal
+		{",", ",", false},                   // not a decimal
+		{"-,", "-,", false},                 // not a decimal
+		{".", ".", false},                   // not a decimal
+		{"-.", "-.", false},                 // not a decimal
+		{"+.", "+.", false},                 // not a decimal
+		{" - .", " - .", false},             // not a decimal
+		{"1·234.56", "1·234.56", false},     // not a decimal
+		{"1·234,56", "1·234,56", false},     // not a decimal
+		{"1·234'56", "1·234'56", false},     // not a decimal
+		{"1,234'56", "1,234'56", false},     // not a decimal
+		{"1 234'56", "1 234'56", false},     // not a decimal
+		{"1 234·56", "1 234·56", false},     // not a decimal
+		{"1'234·56", "1'234·56", false},     // not a decimal
+		{"1.234·56", "1.234·56", false},     // not a decimal
+		{"1'234'56", "1'234'56", false},     // not a decimal
+		{"1 234 56", "1 234 56", false},     // not a decimal
+		{"12.345 678", "12.345 678", false}, // not a decimal
+		{"-1\u00A0234,5", "-1234.5", true},
+		{"1\u202F234\u202F567,25", "1234567.25", true},
+		{"1 234\u00A0567", "1 234\u00A0567", false}, // not a decimal
+	}
+
+	for _, test := range data {
+		got, ok := NormalizeCheck(test.decimal)
+		if got != test.want || ok != test.ok {
+			t.Errorf("NormalizeCheck(%q) = (%q, %v), want (%q, %v)", test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestIsNormalized(t *testing.T) {
+	data := []struct {
+		decimal string
+		want    bool
+	}{
+		{"0", true},
+		{"1230", true},
+		{"-123", true},
+		{"0.1", true},
+		{"-0.1", true},
+		{"123.45", true},
+		{"-123.45", true},
+		{"-0", false},       // not standard 0
+		{"-", false},        // no digits
+		{"--1", false},      // double sign
+		{"", false},         // not a decimal
+		{"a", false},        // not a decimal
+		{"0123", false},     // starts with 0
+		{"-0123", false},    // starts with 0
+		{".", false},        // starts with '.'
+		{".12", false},      // starts with '.'
+		{"0.", false},       // trailing '.'
+		{"-0.", false},      // trailing '.'
+		{"123.", false},     // trailing '.'
+		{"-123.", false},    // trailing '.'
+		{"0.0", false},      // trailing '0'
+		{"0.10", false},     // trailing '0'
+		{"1 234", false},    // hase group separator
+		{"1·234", false},    // hase '·' character
+		{"1 234.56", false}, // hase space
+		{" 1234.56", false}, // hase space
+		{"1234.56 ", false}, // hase space
+	}
+
+	for _, test := range data {
+		got := IsNormalized(test.decimal)
+		if got != test.want {
+			t.Errorf("IsNormalized(%q) = %v, want %v", test.decimal, got, test.want)
+		}
+	}
+}
+
+func ExampleIsNormalized() {
+	fmt.Println(IsNormalized("-123.45"))
+	fmt.Println(IsNormalized("1 234.5"))
+	// Output:
+	// true
+	// false
+}
+
+func TestIsDecimal(t *testing.T) {
+	inputs := []string{
+		"", "-", "+", "0", "-0", "123", " 1 234,5 ", "1,234", "1.234", "1·234", "1,234.5",
+		"1.234,5", "1'34'567", "1 34 567.8", ".5", "12.", "-.5", "1,2,3", "1..2", "12a", "1 23",
+		"--1", "1,234,56", "1·", "·5", "1'234'567·5", "1234567.891",
+	}
+
+	for _, s := range inputs {
+		_, want := NormalizeCheck(s)
+		if got := IsDecimal(s); got != want {
+			t.Errorf("IsDecimal(%q) = %v, want %v", s, got, want)
+		}
+		if got := IsDecimal([]byte(s)); got != want {
+			t.Errorf("IsDecimal([]byte(%q)) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestIsDecimalAllocs(t *testing.T) {
+	decimal := []byte("-1 234 567,891")
+	allocs := testing.AllocsPerRun(100, func() {
+		IsDecimal(decimal)
+	})
+	if allocs != 0 {
+		t.Errorf("IsDecimal allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkIsDecimal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		IsDecimal("-1 234 567,891")
+	}
+}
+
+func TestNormalizeInPlace(t *testing.T) {
+	tests := []string{
+		"0", "-12.5", "1 234,50", "-1,234.5", "+ 1'234'567.890", "12,34,567.8", "1.234,5",
+		"1\u00A0234\u00A0567,5", "1,234·56", "-0,000", "+0", "000123", "007,50", "-,5", ".5", "+.5",
+		" 0,05 ", "5.", "1_000", "1,234", "5.384", "12 kg", "", "1,2,3",
+	}
+
+	for _, test := range tests {
+		want, wantOK := NormalizeCheck(test)
+		got, ok := NormalizeInPlace([]byte(test))
+		if ok != wantOK || (ok && string(got) != want) || (!ok && string(got) != test) {
+			t.Errorf("NormalizeInPlace(%q) = (%q, %v), want (%q, %v)", test, got, ok, want, wantOK)
+		}
+	}
+}
+
+func TestNormalizeInPlaceAllocs(t *testing.T) {
+	decimal := []byte("-1 234 567,891")
+	buf := make([]byte, len(decimal))
+	allocs := testing.AllocsPerRun(100, func() {
+		copy(buf, decimal)
+		NormalizeInPlace(buf)
+	})
+	if allocs != 0 {
+		t.Errorf("NormalizeInPlace allocates %v times, want 0", allocs)
+	}
+}
+
+func ExampleNormalizeInPlace() {
+	buf := []byte("-1 234 567,891")
+	normalized, ok := NormalizeInPlace(buf)
+	fmt.Println(string(normalized), ok, &normalized[0] == &buf[0])
+	// Output: -1234567.891 true true
+}
+
+func ExampleIsDecimal() {
+	fmt.Println(IsDecimal("1 234,5"), IsDecimal("1,234"), IsDecimal("12 apples"))
+	// Output: true false false
+}
+
+func TestConvert(t *testing.T) {
+	data := []struct {
+		df      DecimalFormat
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "123", "123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "+ 1234", "1 234", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "123456789", "123 456 789", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "123456789", "12 34 56 789", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "-23456789", "-2 34 56 789", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "123456789.123", "123 456 789.123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "123456789.123", "12 34 56 789.123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "- 23456789.123", "-2 34 56 789.123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: true}, "+123.456.789,123", "123 456 789.123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, "12 34 56 789,123", "12 34 56 789.123", true},
+		{DecimalFormat{Point: '.', Group: ' ', Standard: false}, " - 23 456 789,123", "-2 34 56 789.123", true},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " - 23 456 789,123", "-2,34,56,789·123", true},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, "", "0", false},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " ", "0", false},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " . ", "0", false},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " -. ", "0", false},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, " - 123 45 6789,123", "0", false},
+	}
+
+	for _, test := range data {
+		got, ok := test.df.Convert(test.decimal)
+		if got != test.want || ok != test.ok {
+			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleDecimalFormat_Convert() {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	new, ok := df.Convert("123456789.123")
+	if !ok {
+		fmt.Println("not a decimal")
+	}
+	fmt.Println(new)
+	// Output: 123 456 789,123
+}
+
+func TestConvertErr(t *testing.T) {
+	fr := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	integers := DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}
+	tests := []struct {
+		df      DecimalFormat
+		decimal string
+		opts    []Option
+		want    string
+		err     error
+	}{
+		{fr, "1234.5", nil, "1 234,5", nil},
+		{fr, "0", nil, "0", nil},
+		{fr, "1.5", []Option{WithMinScale(2)}, "1,50", nil},
+		{fr, "NaN", []Option{WithSpecialValues()}, "NaN", nil},
+		{fr, "abc", nil, "", ErrInvalid},
+		{fr, "", nil, "", ErrInvalid},
+		{integers, "1234.5", nil, "", ErrInvalid},
+		{integers, "1234", nil, "1,234", nil},
+		{DecimalFormat{Point: ',', Group: ','}, "1", nil, "", ErrInvalidFormat},
+		{DecimalFormat{Point: ',', Group: '.', Standard: true}, "1", []Option{WithStrictness(SIStrict)}, "", ErrInvalidFormat},
+		{DecimalFormat{Point: ',', Group: '\u202F', Standard: true}, "12345.5", []Option{WithStrictness(SIStrict)}, "12\u202F345,5", nil},
+		{DecimalFormat{Point: '\u066B', Group: '\u202F', Standard: true}, "1", []Option{WithStrictness(SIStrict)}, "", ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		got, err := test.df.ConvertErr(test.decimal, test.opts...)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("(%v).ConvertErr(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleDecimalFormat_ConvertErr() {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	for _, amount := range []string{"1234.5", "12 apples"} {
+		s, err := df.ConvertErr(amount)
+		fmt.Printf("%q %v\n", s, err)
+	}
+	// Output:
+	// "1 234,5" <nil>
+	// "" decstr: invalid decimal string: "12 apples"
+}
+
+// Example demonstrates general usage of the decstr package, including
+// normalization, format detection, and conversion of decimal strings.
+func Example() {
+	decimal := "1'234'567,89"
+
+	// Normalize example
+	normalized := Normalize(decimal)
+	fmt.Println("Normalized:", normalized)
+
+	// Detect format example
+	format, ok := DetectFormat(decimal)
+	fmt.Println("Detected format:", format, "ok:", ok)
+	// Convert example
+	df := DecimalFormat{Point: '.', Group: ' ', Standard: false}
+	converted, ok := df.Convert(decimal)
+	fmt.Println("Converted:", converted, "ok:", ok)
+	// Output:
+	// Normalized: 1234567.89
+	// Detected format: {`,`, `'`, standard} ok: true
+	// Converted: 12 34 567.89 ok: true
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		df DecimalFormat
+		ok bool
+	}{
+		{DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, true},
+		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
+		{DecimalFormat{Point: '·', Group: NoSeparator, Standard: true}, true},
+		{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false}, true},
+		{DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, true},
+		{DecimalFormat{Point: ',', Group: '.', Standard: true}, true},
+		{DecimalFormat{Point: '·', Group: ',', Standard: false}, true},
+		{DecimalFormat{Point: '\'', Group: '.', Standard: true}, true},
+		{DecimalFormat{Point: '.', Group: '.', Standard: true}, false},
+		{DecimalFormat{Point: ',', Group: ',', Standard: true}, false},
+		{DecimalFormat{Point: ';', Group: NoSeparator, Standard: true}, false},
+		{DecimalFormat{Point: NoSeparator, Group: '·', Standard: true}, false},
+		{DecimalFormat{Point: '\'', Group: ',', Standard: true}, false},
+		{DecimalFormat{Point: '·', Group: ' ', Standard: true}, false},
+	}
+
+	for _, test := range tests {
+		err := test.df.Validate()
+		if (err == nil) != test.ok {
+			t.Errorf("(%v).Validate() = %v, want ok: %v", test.df, err, test.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("(%v).Validate() = %v, want ErrInvalidFormat", test.df, err)
+		}
+		df, err := NewDecimalFormat(test.df.Point, test.df.Group, test.df.Standard)
+		if df != test.df || (err == nil) != test.ok {
+			t.Errorf("NewDecimalFormat(%q, %q, %v) = (%v, %v), want ok: %v", test.df.Point, test.df.Group, test.df.Standard, df, err, test.ok)
+		}
+	}
+}
+
+func TestIsValidSeparatorPair(t *testing.T) {
+	tests := []struct {
+		point, group rune
+		want         bool
+	}{
+		{',', ' ', true},
+		{',', '.', true},
+		{'.', ',', true},
+		{'·', ',', true},
+		{'\'', '.', true},
+		{'.', '.', false},
+		{'·', ' ', false},
+		{'\'', ',', false},
+		{NoSeparator, ' ', false},
+		{'.', NoSeparator, false},
+	}
+
+	for _, test := range tests {
+		got := IsValidSeparatorPair(test.point, test.group)
+		if got != test.want {
+			t.Errorf("IsValidSeparatorPair(%q, %q) = %v, want %v", test.point, test.group, got, test.want)
+		}
+	}
+}
+
+func TestRegisterSeparatorPair(t *testing.T) {
+	// use separators unknown to the detection to not interfere with other tests
+	if IsValidSeparatorPair(';', '~') {
+		t.Fatalf("IsValidSeparatorPair(';', '~') = true before registration")
+	}
+	// restore the registry, as the registered decimal separator ';' becomes valid alone
+	groupingMu.Lock()
+	saved := make(map[rune][]rune, len(possibleGrouping))
+	for point, groups := range possibleGrouping {
+		saved[point] = slices.Clone(groups)
+	}
+	groupingMu.Unlock()
+	t.Cleanup(func() {
+		groupingMu.Lock()
+		possibleGrouping = saved
+		groupingMu.Unlock()
+	})
+	if err := RegisterSeparatorPair(';', '~'); err != nil {
+		t.Fatalf("RegisterSeparatorPair(';', '~') = %v", err)
+	}
+	if err := RegisterSeparatorPair(';', '~'); err != nil {
+		t.Fatalf("RegisterSeparatorPair(';', '~') twice = %v", err)
+	}
+	if !IsValidSeparatorPair(';', '~') {
+		t.Errorf("IsValidSeparatorPair(';', '~') = false after registration")
+	}
+	if err := (DecimalFormat{Point: ';', Group: '~', Standard: true}).Validate(); err != nil {
+		t.Errorf("Validate of registered pair = %v", err)
+	}
+	if err := (DecimalFormat{Point: NoSeparator, Group: '~', Standard: true}).Validate(); err != nil {
+		t.Errorf("Validate of registered group = %v", err)
+	}
+	for _, pair := range [][2]rune{{';', ';'}, {NoSeparator, ';'}, {';', NoSeparator}} {
+		if err := RegisterSeparatorPair(pair[0], pair[1]); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("RegisterSeparatorPair(%q, %q) = %v, want ErrInvalidFormat", pair[0], pair[1], err)
+		}
+	}
+}
+
+func TestConvertFormats(t *testing.T) {
+	data := []struct {
+		df      DecimalFormat
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{DecimalFormat{Point: '.', Group: '_', Standard: true}, "1234567.89", "1_234_567.89", true},
+		{DecimalFormat{Point: '.', Group: '_', Standard: true}, "1_234_567.89", "1_234_567.89", true},
+		{DecimalFormat{Point: NoSeparator, Group: '_', Standard: true}, "-1234567", "-1_234_567", true},
+		{DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "1 234 567,8", "1234567.8", true},
+		{DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, "1234567", "1234567", true},
+		{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "1234.5", "0", false},
+		{DecimalFormat{Point: ',', Group: '_', Standard: true}, "1234", "0", false},
+		{DecimalFormat{Point: '.', Group: '.', Standard: true}, "1234", "0", false},
+	}
+
+	for _, test := range data {
+		got, ok := test.df.Convert(test.decimal)
+		if got != test.want || ok != test.ok {
+			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestConvertGeneric(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	tests := []struct {
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{"1234567.891", "1.234.567,891", true},
+		{"-1 234,5", "-1.234,5", true},
+		{"1,234", "0", false},
+		{"", "0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := Convert(df, test.decimal)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Convert(%v, %q) = (%q, %v), want (%q, %v)", df, test.decimal, got, ok, test.want, test.ok)
+		}
+		bgot, bok := Convert(df, []byte(test.decimal))
+		if string(bgot) != test.want || bok != test.ok {
+			t.Errorf("Convert(%v, []byte(%q)) = (%q, %v), want (%q, %v)", df, test.decimal, bgot, bok, test.want, test.ok)
+		}
+	}
+	if got, ok := Convert(df, []byte("nan"), WithSpecialValues()); string(got) != "NaN" || !ok {
+		t.Errorf("Convert(%v, []byte(\"nan\"), WithSpecialValues()) = (%q, %v), want (\"NaN\", true)", df, got, ok)
+	}
+	// the generic function and the method share their conversion
+	for _, opts := range [][]Option{nil, {WithMinScale(2)}, {WithPlusSign(), WithWidth(12)}, {WithStrictness(SIStrict)}, {WithAccounting()}} {
+		for _, f := range []DecimalFormat{df, {Point: '.', Group: NoSeparator, Standard: true}, {Point: ',', Group: '\u202F', Standard: true}} {
+			want, wok := f.Convert("-12345.678", opts...)
+			if got, ok := Convert(f, []byte("-12345.678"), opts...); string(got) != want || ok != wok {
+				t.Errorf("Convert(%v, []byte(%q), %d options) = (%q, %v), want (%q, %v)", f, "-12345.678", len(opts), got, ok, want, wok)
+			}
+		}
+	}
+	// an unchanged []byte is not the input itself
+	in := []byte("1234.5")
+	if got, _ := Convert(DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, in); &got[0] == &in[0] {
+		t.Errorf("Convert(%q) returns its input", in)
+	}
+}
+
+func ExampleConvert() {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	converted, _ := Convert(df, []byte("1234567.89"))
+	fmt.Printf("%s\n", converted)
+	// Output: 1 234 567,89
+}
@@ -0,0 +1,214 @@
+// decstrcsv is a package for normalizing or converting the decimal columns of CSV files.
+// It wraps an encoding/csv Reader and uses decstr to detect the format of each decimal column.
+package decstrcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kpym/decstr"
+)
+
+// DefaultInferRows is the number of records used to detect the decimal columns
+// and their formats, if Reader.InferRows is 0.
+const DefaultInferRows = 100
+
+// Reader reads records from a csv.Reader and normalizes (or converts) the values
+// of its decimal columns. The format of each decimal column is detected using
+// the first InferRows records (see decstr.DetectFormatAll), so that ambiguous
+// values like "1,234" are resolved using the other values of the column.
+// Blank values are returned unchanged.
+//
+// The exported fields can be changed before the first call to Read or ReadAll.
+type Reader struct {
+	// Header indicates that the first record is a header, returned unchanged.
+	Header bool
+	// Columns are the (0 based) indexes of the decimal columns.
+	// If nil, the decimal columns are the ones whose non-blank values in the
+	// first InferRows records are all decimal strings.
+	Columns []int
+	// InferRows is the number of records used to detect the decimal columns and their formats.
+	// If 0, DefaultInferRows is used.
+	InferRows int
+	// Format is the format to convert the decimal values to.
+	// If nil, the decimal values are normalized.
+	Format *decstr.DecimalFormat
+
+	r        *csv.Reader
+	started  bool                         // if the first records are read
+	line     int                          // the number of records returned
+	buffered [][]string                   // the records read to detect the formats
+	err      error                        // the error met while reading the first records
+	formats  map[int]decstr.DecimalFormat // the detected format of each decimal column
+	columns  map[int]bool                 // the decimal columns
+}
+
+// NewReader returns a new Reader that reads from r.
+func NewReader(r *csv.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Read reads one record from r with its decimal values normalized or converted.
+// The record is modified in place.
+// If a decimal value is not valid, it returns the record unchanged and an error
+// wrapping decstr.ErrInvalid, decstr.ErrAmbiguous or decstr.ErrInconsistent.
+func (r *Reader) Read() (record []string, err error) {
+	if !r.started {
+		r.start()
+	}
+	if len(r.buffered) > 0 {
+		record, r.buffered = r.buffered[0], r.buffered[1:]
+	} else if r.err != nil {
+		return nil, r.err
+	} else if record, err = r.r.Read(); err != nil {
+		return nil, err
+	}
+	r.line++
+	if r.Header && r.line == 1 {
+		return record, nil
+	}
+	converted := make([]string, len(record))
+	for i, value := range record {
+		converted[i] = value
+		if !r.columns[i] {
+			continue
+		}
+		if converted[i], err = r.convert(i, value); err != nil {
+			return record, fmt.Errorf("decstrcsv: record %d, column %d: %w", r.line, i, err)
+		}
+	}
+	copy(record, converted)
+	return record, nil
+}
+
+// ReadAll reads all the remaining records from r (see Read).
+func (r *Reader) ReadAll() (records [][]string, err error) {
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// start reads the first records and detects the decimal columns and their formats.
+func (r *Reader) start() {
+	r.started = true
+	n := r.InferRows
+	if n <= 0 {
+		n = DefaultInferRows
+	}
+	if r.Header {
+		n++
+	}
+	for len(r.buffered) < n {
+		record, err := r.r.Read()
+		if err != nil {
+			r.err = err
+			break
+		}
+		// copy the record in case the csv.Reader reuses it
+		r.buffered = append(r.buffered, append([]string(nil), record...))
+	}
+	sample := r.buffered
+	if r.Header && len(sample) > 0 {
+		sample = sample[1:]
+	}
+
+	// the candidate columns
+	columns := r.Columns
+	if columns == nil {
+		for _, record := range sample {
+			for len(columns) < len(record) {
+				columns = append(columns, len(columns))
+			}
+		}
+	}
+
+	r.columns = make(map[int]bool, len(columns))
+	r.formats = make(map[int]decstr.DecimalFormat, len(columns))
+	for _, i := range columns {
+		values := make([]string, 0, len(sample))
+		for _, record := range sample {
+			if i < len(record) {
+				values = append(values, record[i])
+			}
+		}
+		df, err := decstr.DetectFormatAll(values)
+		switch {
+		case err == nil:
+			r.columns[i] = true
+			r.formats[i] = df
+		case r.Columns != nil || errors.Is(err, decstr.ErrAmbiguous):
+			// declared columns are decimal even if the format is not detected
+			r.columns[i] = true
+		}
+	}
+}
+
+// convert normalizes (or converts) the value of the column i.
+func (r *Reader) convert(i int, value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return value, nil
+	}
+	src, known := r.formats[i]
+	normalized, err := normalize(value, src, known)
+	if err != nil {
+		return "", err
+	}
+	if r.Format == nil {
+		return normalized, nil
+	}
+	converted, ok := r.Format.Convert(normalized)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", decstr.ErrInvalid, value)
+	}
+	return converted, nil
+}
+
+// normalize normalizes the value, using the column format src (if known)
+// to check the value format and to resolve ambiguous values.
+func normalize(value string, src decstr.DecimalFormat, known bool) (string, error) {
+	formats := decstr.DetectFormats(value)
+	switch {
+	case len(formats) == 0:
+		return "", fmt.Errorf("%w: %q", decstr.ErrInvalid, value)
+	case len(formats) == 1:
+		if known && !compatible(formats[0], src) {
+			return "", fmt.Errorf("%w: %q is not in the column format %v", decstr.ErrInconsistent, value, src)
+		}
+		return decstr.Normalize(value), nil
+	case !known:
+		return "", fmt.Errorf("%w: %q", decstr.ErrAmbiguous, value)
+	}
+
+	// the ambiguous case: formats[0] is the grouping interpretation, formats[1] the decimal one
+	sep := string(formats[1].Point)
+	switch {
+	case formats[1].Point == src.Point || (src.Point == decstr.NoSeparator && src.Group != decstr.NoSeparator && formats[0].Group != src.Group):
+		// '·' is never ambiguous
+		return decstr.Normalize(strings.Replace(value, sep, "·", 1)), nil
+	case formats[0].Group == src.Group || (src.Group == decstr.NoSeparator && src.Point != decstr.NoSeparator):
+		return decstr.Normalize(strings.Replace(value, sep, "", 1)), nil
+	default:
+		return "", fmt.Errorf("%w: %q", decstr.ErrAmbiguous, value)
+	}
+}
+
+// compatible checks if a value of format df can belong to a column of format src.
+// A column format may lack a separator if no value of the sample uses it,
+// so a value can use a separator that is not used by the other one.
+func compatible(df, src decstr.DecimalFormat) bool {
+	pointOK := df.Point == decstr.NoSeparator || df.Point == src.Point ||
+		(src.Point == decstr.NoSeparator && df.Point != src.Group)
+	groupOK := df.Group == decstr.NoSeparator || df.Group == src.Group ||
+		(src.Group == decstr.NoSeparator && df.Group != src.Point)
+	return pointOK && groupOK
+}
@@ -0,0 +1,106 @@
+package decstrcsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kpym/decstr"
+)
+
+func TestReader(t *testing.T) {
+	de := decstr.DecimalFormat{Point: ',', Group: '.', Standard: true}
+	tests := []struct {
+		name    string
+		input   string
+		header  bool
+		columns []int
+		format  *decstr.DecimalFormat
+		want    [][]string
+		err     error
+	}{
+		{
+			name:  "infer",
+			input: "a,\"1 234,5\",x\nb,\"1,234\",2\nc,,3\n",
+			want:  [][]string{{"a", "1234.5", "x"}, {"b", "1.234", "2"}, {"c", "", "3"}},
+		},
+		{
+			name:   "header",
+			input:  "name,value\na,\"1,234\"\nb,\"1,234,567\"\n",
+			header: true,
+			want:   [][]string{{"name", "value"}, {"a", "1234"}, {"b", "1234567"}},
+		},
+		{
+			name:   "convert",
+			input:  "a,\"1,234.5\"\nb,\"1,234\"\n",
+			format: &de,
+			want:   [][]string{{"a", "1.234,5"}, {"b", "1.234"}},
+		},
+		{
+			name:    "declared columns",
+			input:   "1,2\n3,4\n",
+			columns: []int{1},
+			format:  &de,
+			want:    [][]string{{"1", "2"}, {"3", "4"}},
+		},
+		{
+			name:    "declared invalid",
+			input:   "1,abc\n",
+			columns: []int{1},
+			err:     decstr.ErrInvalid,
+		},
+		{
+			name:  "ambiguous",
+			input: "\"1,234\"\n\"2,345\"\n",
+			err:   decstr.ErrAmbiguous,
+		},
+	}
+
+	for _, test := range tests {
+		r := NewReader(csv.NewReader(strings.NewReader(test.input)))
+		r.Header, r.Columns, r.Format = test.header, test.columns, test.format
+		got, err := r.ReadAll()
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("%s: ReadAll() error = %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if test.err == nil && !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: ReadAll() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReaderInferRows(t *testing.T) {
+	// the first row is not enough to resolve the ambiguity of the second one
+	input := "\"1,5\"\n\"1,234\"\n"
+	r := NewReader(csv.NewReader(strings.NewReader(input)))
+	r.InferRows = 1
+	got, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"1.5"}, {"1.234"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadAll() = %q, want %q", got, want)
+	}
+	// inconsistent value after the sample
+	r = NewReader(csv.NewReader(strings.NewReader("\"1,5\"\n\"1.5\"\n")))
+	r.InferRows = 1
+	if _, err := r.ReadAll(); !errors.Is(err, decstr.ErrInconsistent) {
+		t.Errorf("ReadAll() error = %v, want %v", err, decstr.ErrInconsistent)
+	}
+}
+
+func ExampleReader() {
+	input := "item,price\napple,\"1,5\"\npear,\"1,234\"\n"
+	r := NewReader(csv.NewReader(strings.NewReader(input)))
+	r.Header = true
+	records, err := r.ReadAll()
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(records)
+	// Output: [[item price] [apple 1.5] [pear 1.234]]
+}
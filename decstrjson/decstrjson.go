@@ -0,0 +1,205 @@
+// decstrjson is a package for normalizing or converting the decimal values of JSON documents.
+// It walks the document with an encoding/json Decoder and rewrites only the decimal values,
+// copying all the other bytes (spaces, escapes, key order) unchanged.
+package decstrjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kpym/decstr"
+)
+
+// Rewriter rewrites the decimal values of JSON documents.
+// The number values are normalized, like 1234.50 -> 1234.5 (numbers with an exponent are unchanged),
+// and the string values that are decimal strings are normalized, like "1.234,50" -> "1234.5".
+// The object keys, the blank strings, the booleans and the nulls are never rewritten.
+//
+// The zero value normalizes all the decimal values.
+type Rewriter struct {
+	// Keys are the object keys whose values are rewritten.
+	// If nil, all the values are candidates and the strings that are not (or not
+	// unambiguously) decimal strings are kept unchanged. If not nil, only the values of
+	// these keys (or of the arrays of these keys) are rewritten, and a string that is not a decimal string is an error.
+	//
+	// Beware that with nil Keys every string that parses as a decimal is rewritten,
+	// including the identifiers and codes made of digits: the zip code "01234" becomes "1234"
+	// and the reference "1 000" becomes "1000". Set Keys when the documents hold such strings.
+	Keys []string
+	// Format is the format to convert the decimal values to.
+	// If nil, the decimal values are normalized.
+	// The number values stay numbers, and so are only normalized, unless QuoteNumbers is set.
+	Format *decstr.DecimalFormat
+	// QuoteNumbers makes the number values converted to Format and written as strings.
+	QuoteNumbers bool
+	// Options are used to parse the string values (see decstr.Parse)
+	// and to convert the decimal values (see decstr.DecimalFormat.Convert).
+	Options []decstr.Option
+}
+
+// Rewrite copies the JSON documents read from r to w, with their decimal values normalized.
+// It is a shortcut for (&Rewriter{}).Rewrite(w, r), so all the strings that parse as decimals
+// are rewritten, like the identifier "01234" (see Rewriter.Keys).
+func Rewrite(w io.Writer, r io.Reader) error {
+	return (&Rewriter{}).Rewrite(w, r)
+}
+
+// flushSize is the size of the input kept in memory before being copied to the output.
+const flushSize = 4096
+
+// frame is the state of an open JSON object or array.
+type frame struct {
+	object bool   // if it is an object
+	key    bool   // if the next token of the object is a key
+	name   string // the last key of the object, or the key of the array
+}
+
+// Rewrite copies the JSON documents read from r to w, rewriting their decimal values.
+// The input is processed as a stream: a sequence of documents (like JSON lines) is accepted,
+// and only a small part of it is kept in memory.
+// It stops at the first syntax error of the JSON input, or at the first invalid
+// decimal string of a value listed in Keys, returning an error giving its offset.
+func (rw *Rewriter) Rewrite(w io.Writer, r io.Reader) error {
+	var keys map[string]bool
+	if rw.Keys != nil {
+		keys = make(map[string]bool, len(rw.Keys))
+		for _, key := range rw.Keys {
+			keys[key] = true
+		}
+	}
+
+	// raw holds the input read by the decoder, from the offset base
+	var raw bytes.Buffer
+	dec := json.NewDecoder(io.TeeReader(r, &raw))
+	dec.UseNumber()
+	out := bufio.NewWriter(w)
+	var (
+		base  int64   // the offset of the first byte of raw
+		prev  int64   // the offset of the end of the previous token
+		stack []frame // the open objects and arrays
+	)
+	for ; ; prev = dec.InputOffset() {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("decstrjson: offset %d: %w", dec.InputOffset(), err)
+		}
+		if prev-base > flushSize {
+			out.Write(raw.Next(int(prev - base)))
+			base = prev
+		}
+		if !rw.candidate(tok, &stack, keys) {
+			continue
+		}
+		// the token starts after the separators following the previous token
+		end := dec.InputOffset()
+		between := raw.Bytes()[prev-base : end-base]
+		start := end - int64(len(bytes.TrimLeft(between, " \t\r\n,:")))
+		value, err := rw.rewrite(tok, keys != nil)
+		if err != nil {
+			return fmt.Errorf("decstrjson: offset %d: %w", start, err)
+		}
+		if value == nil {
+			continue
+		}
+		out.Write(raw.Next(int(start - base)))
+		out.Write(value)
+		raw.Next(int(end - start))
+		base = end
+	}
+	out.Write(raw.Bytes())
+	return out.Flush()
+}
+
+// candidate updates the stack of open objects and arrays with tok,
+// and reports if tok is a value to rewrite.
+func (rw *Rewriter) candidate(tok json.Token, stack *[]frame, keys map[string]bool) bool {
+	if delim, ok := tok.(json.Delim); ok {
+		if delim == '{' || delim == '[' {
+			// the arrays inherit the key of their parent object
+			f := frame{object: delim == '{', key: true}
+			if n := len(*stack); n > 0 && !f.object {
+				f.name = (*stack)[n-1].name
+			}
+			*stack = append(*stack, f)
+			return false
+		}
+		*stack = (*stack)[:len(*stack)-1]
+		if len(*stack) > 0 {
+			(*stack)[len(*stack)-1].key = true
+		}
+		return false
+	}
+	if len(*stack) == 0 {
+		return keys == nil
+	}
+	top := &(*stack)[len(*stack)-1]
+	if !top.object {
+		return keys == nil || keys[top.name]
+	}
+	if top.key {
+		// the object keys are decoded as strings
+		top.name, top.key = tok.(string), false
+		return false
+	}
+	top.key = true
+	return keys == nil || keys[top.name]
+}
+
+// rewrite returns the JSON encoding of the rewritten value of tok,
+// or nil if tok is kept unchanged.
+// If strict is set, the strings that are not decimal strings are errors.
+func (rw *Rewriter) rewrite(tok json.Token, strict bool) ([]byte, error) {
+	switch v := tok.(type) {
+	case json.Number:
+		// the JSON numbers use the '.' decimal separator, and '·' is never ambiguous
+		normalized, ok := decstr.NormalizeCheck(strings.Replace(string(v), ".", "·", 1))
+		if !ok {
+			// the exponent notation
+			return nil, nil
+		}
+		if rw.Format == nil || !rw.QuoteNumbers {
+			if normalized == string(v) {
+				return nil, nil
+			}
+			return []byte(normalized), nil
+		}
+		return rw.convert(normalized)
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, nil
+		}
+		normalized, _, err := decstr.Parse(v, rw.Options...)
+		if err != nil {
+			if strict {
+				return nil, err
+			}
+			return nil, nil
+		}
+		if rw.Format == nil {
+			if normalized == v {
+				return nil, nil
+			}
+			return json.Marshal(normalized)
+		}
+		return rw.convert(normalized)
+	default:
+		// booleans and nulls
+		return nil, nil
+	}
+}
+
+// convert returns the JSON string of the normalized decimal converted to rw.Format.
+func (rw *Rewriter) convert(normalized string) ([]byte, error) {
+	converted, ok := rw.Format.Convert(normalized, rw.Options...)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", decstr.ErrInvalidFormat, *rw.Format)
+	}
+	return json.Marshal(converted)
+}
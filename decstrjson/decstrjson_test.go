@@ -0,0 +1,151 @@
+package decstrjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/kpym/decstr"
+)
+
+func TestRewrite(t *testing.T) {
+	de := decstr.DecimalFormat{Point: ',', Group: '.', Standard: true}
+	tests := []struct {
+		name  string
+		input string
+		rw    Rewriter
+		want  string
+		err   error
+	}{
+		{
+			name:  "normalize",
+			input: `{"amount": "1.234,50", "n": 12.50, "e": 1.5e3, "text": "abc", "ok": true, "nil": null}`,
+			want:  `{"amount": "1234.5", "n": 12.5, "e": 1.5e3, "text": "abc", "ok": true, "nil": null}`,
+		},
+		{
+			name:  "keep layout",
+			input: "{\n  \"a\" : [ \"1 234,5\" ,\t-0.0 ],\n  \"1 234,5\": \"x\\u0041\"\n}\n",
+			want:  "{\n  \"a\" : [ \"1234.5\" ,\t0 ],\n  \"1 234,5\": \"x\\u0041\"\n}\n",
+		},
+		{
+			name:  "ambiguous kept",
+			input: `["1,234", "", "  "]`,
+			want:  `["1,234", "", "  "]`,
+		},
+		{
+			// without Keys, the identifiers made of digits are rewritten too
+			name:  "identifiers",
+			input: `{"zip": "01234", "ref": "1 000", "id": "A-1234"}`,
+			want:  `{"zip": "1234", "ref": "1000", "id": "A-1234"}`,
+		},
+		{
+			name:  "identifiers with keys",
+			input: `{"zip": "01234", "ref": "1 000", "total": "1 000"}`,
+			rw:    Rewriter{Keys: []string{"total"}},
+			want:  `{"zip": "01234", "ref": "1 000", "total": "1000"}`,
+		},
+		{
+			name:  "lenient",
+			input: `["1,234"]`,
+			rw:    Rewriter{Options: []decstr.Option{decstr.WithStrictness(decstr.Lenient)}},
+			want:  `["1234"]`,
+		},
+		{
+			name:  "keys",
+			input: `{"zip": "01234", "amount": "1 234,5", "items": [{"amount": 1.50}], "amounts": ["0,5", null]}`,
+			rw:    Rewriter{Keys: []string{"amount", "amounts"}},
+			want:  `{"zip": "01234", "amount": "1234.5", "items": [{"amount": 1.5}], "amounts": ["0.5", null]}`,
+		},
+		{
+			name:  "keys invalid",
+			input: `{"amount": "12 apples"}`,
+			rw:    Rewriter{Keys: []string{"amount"}},
+			err:   decstr.ErrInvalid,
+		},
+		{
+			name:  "convert",
+			input: `{"a": "1,234.5", "n": 1234.5}`,
+			rw:    Rewriter{Format: &de},
+			want:  `{"a": "1.234,5", "n": 1234.5}`,
+		},
+		{
+			name:  "quote numbers",
+			input: `{"a": "1,234.5", "n": 1234.5}`,
+			rw:    Rewriter{Format: &de, QuoteNumbers: true, Options: []decstr.Option{decstr.WithMinScale(2)}},
+			want:  `{"a": "1.234,50", "n": "1.234,50"}`,
+		},
+		{
+			name:  "json lines",
+			input: "{\"a\": \"1 000\"}\n{\"a\": 2.0}\n",
+			want:  "{\"a\": \"1000\"}\n{\"a\": 2}\n",
+		},
+	}
+
+	for _, test := range tests {
+		var out strings.Builder
+		err := test.rw.Rewrite(&out, iotest.HalfReader(strings.NewReader(test.input)))
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: Rewrite() error = %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: Rewrite() error = %v", test.name, err)
+			continue
+		}
+		if got := out.String(); got != test.want {
+			t.Errorf("%s: Rewrite() = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestRewriteSyntaxError(t *testing.T) {
+	var out strings.Builder
+	err := Rewrite(&out, strings.NewReader(`{"a": 1.5,}`))
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Rewrite() error = %v, want a *json.SyntaxError", err)
+	}
+}
+
+func TestRewriteLarge(t *testing.T) {
+	// larger than flushSize, to check the copy of the kept input
+	var in, want strings.Builder
+	in.WriteString("[")
+	want.WriteString("[")
+	for i := range 2000 {
+		if i > 0 {
+			in.WriteString(", ")
+			want.WriteString(", ")
+		}
+		if i%100 == 0 {
+			fmt.Fprintf(&in, `"%d,50"`, i)
+			fmt.Fprintf(&want, `"%d.5"`, i)
+		} else {
+			fmt.Fprintf(&in, `"x%d"`, i)
+			fmt.Fprintf(&want, `"x%d"`, i)
+		}
+	}
+	in.WriteString("]")
+	want.WriteString("]")
+	var out strings.Builder
+	if err := Rewrite(&out, strings.NewReader(in.String())); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != want.String() {
+		t.Errorf("Rewrite() of a large array differs from the expected output")
+	}
+}
+
+func ExampleRewrite() {
+	input := `{"id": "A-12", "amount": "1.234,56", "fee": 2.50}`
+	if err := Rewrite(os.Stdout, strings.NewReader(input)); err != nil {
+		fmt.Println(err)
+	}
+	// Output: {"id": "A-12", "amount": "1234.56", "fee": 2.5}
+}
@@ -0,0 +1,89 @@
+// decstrtext is a package connecting decstr to the golang.org/x/text packages.
+// It finds the decimal format of a language.Tag from the numbers formatted by x/text/number
+// (with its options), returns the x/text/number options writing a decimal format,
+// and parses these numbers back without ambiguity.
+package decstrtext
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kpym/decstr"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// sample is the number formatted by x/text to find the decimal format of a language.
+const sample = -1234567.5
+
+// replacer maps the characters used by x/text to the ones known by decstr: the no-break spaces
+// to the space, the right single quotation mark to the apostrophe, and the minus sign to '-'.
+var replacer = strings.NewReplacer("\u00a0", " ", "\u202f", " ", "\u2019", "'", "\u2212", "-")
+
+// FormatForTag returns the DecimalFormat used by x/text/number for the language tag,
+// like {',', ' ', standard} for language.French (with a space instead of the no-break space),
+// or {'.', ',', non-standard} for the indian english "en-IN".
+// The languages that do not use the ASCII digits, like arabic, are not supported:
+// the error then wraps decstr.ErrLanguage.
+func FormatForTag(tag language.Tag) (decstr.DecimalFormat, error) {
+	return FormatForOptions(tag)
+}
+
+// FormatForOptions is FormatForTag for the numbers formatted by x/text/number with the options,
+// like {',', none} for language.French and number.NoSeparator().
+// The options limiting the fraction digits, like number.MaxFractionDigits(0),
+// give a format without decimal separator.
+func FormatForOptions(tag language.Tag, opts ...number.Option) (decstr.DecimalFormat, error) {
+	s := message.NewPrinter(tag).Sprint(number.Decimal(sample, opts...))
+	df, ok := decstr.DetectFormat(replacer.Replace(s))
+	if !ok {
+		return decstr.DecimalFormat{}, fmt.Errorf("%w: %v formats numbers like %q", decstr.ErrLanguage, tag, s)
+	}
+	return df, nil
+}
+
+// NumberOptions returns the x/text/number options that make the numbers formatted for the language tag
+// use the decimal format df: none if it is the format of the tag (see FormatForTag),
+// and number.NoSeparator() if df is this format without grouping separator.
+// As x/text/number uses the separators of the tag, the other formats cannot be written,
+// and the error then wraps decstr.ErrMismatch (or is the one of FormatForTag).
+func NumberOptions(tag language.Tag, df decstr.DecimalFormat) ([]number.Option, error) {
+	var opts []number.Option
+	if df.Group == decstr.NoSeparator {
+		opts = append(opts, number.NoSeparator())
+	}
+	got, err := FormatForOptions(tag, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// the grouping style does not matter without grouping separator
+	if got.Point != df.Point || got.Group != df.Group || (df.Group != decstr.NoSeparator && got.Standard != df.Standard) {
+		return nil, fmt.Errorf("%w %v: %v formats numbers in %v", decstr.ErrMismatch, df, tag, got)
+	}
+	return opts, nil
+}
+
+// Parse returns the normalized version of a decimal string formatted for the language tag,
+// like the output of x/text/number with the options (see FormatForOptions).
+// As the format is known, there is no ambiguity: Parse(language.German, "1.234")
+// returns "1234", and Parse(language.English, "1.234") returns "1.234".
+// The errors are the ones of FormatForOptions, or wrap decstr.ErrMismatch if s does not use the format.
+func Parse(tag language.Tag, s string, opts ...number.Option) (string, error) {
+	df, err := FormatForOptions(tag, opts...)
+	if err != nil {
+		return "", err
+	}
+	s = replacer.Replace(s)
+	if err := df.MatchesErr(s); err != nil {
+		return "", err
+	}
+	if df.Group != decstr.NoSeparator {
+		s = strings.ReplaceAll(s, string(df.Group), "")
+	}
+	if df.Point != decstr.NoSeparator {
+		// '·' is never ambiguous for decstr
+		s = strings.Replace(s, string(df.Point), "·", 1)
+	}
+	return decstr.Normalize(s), nil
+}
@@ -0,0 +1,187 @@
+package decstrtext
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kpym/decstr"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+func TestFormatForTag(t *testing.T) {
+	tests := []struct {
+		tag  language.Tag
+		want decstr.DecimalFormat
+		err  error
+	}{
+		{language.English, decstr.DecimalFormat{Point: '.', Group: ',', Standard: true}, nil},
+		{language.French, decstr.DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{language.German, decstr.DecimalFormat{Point: ',', Group: '.', Standard: true}, nil},
+		{language.MustParse("de-CH"), decstr.DecimalFormat{Point: '.', Group: '\'', Standard: true}, nil},
+		{language.MustParse("en-IN"), decstr.DecimalFormat{Point: '.', Group: ',', Standard: false}, nil},
+		{language.Arabic, decstr.DecimalFormat{}, decstr.ErrLanguage},
+	}
+
+	for _, test := range tests {
+		got, err := FormatForTag(test.tag)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("FormatForTag(%v) = (%v, %v), want (%v, %v)", test.tag, got, err, test.want, test.err)
+		}
+	}
+}
+
+// TestParseRoundTrip parses back the numbers formatted by x/text.
+func TestParseRoundTrip(t *testing.T) {
+	tags := []string{"en", "fr", "de", "de-CH", "en-IN", "it", "es", "pt-BR", "ru", "ja", "pl", "sv"}
+	numbers := []struct {
+		value      float64
+		normalized string
+	}{
+		{-1234567.5, "-1234567.5"},
+		{1234, "1234"},
+		{0.25, "0.25"},
+		{12.125, "12.125"},
+		{987654321, "987654321"},
+	}
+	for _, name := range tags {
+		tag := language.MustParse(name)
+		p := message.NewPrinter(tag)
+		for _, n := range numbers {
+			s := p.Sprint(number.Decimal(n.value, number.MaxFractionDigits(3)))
+			got, err := Parse(tag, s)
+			if got != n.normalized || err != nil {
+				t.Errorf("Parse(%v, %q) = (%q, %v), want %q", tag, s, got, err, n.normalized)
+			}
+		}
+	}
+}
+
+func TestFormatForOptions(t *testing.T) {
+	tests := []struct {
+		tag  language.Tag
+		opts []number.Option
+		want decstr.DecimalFormat
+	}{
+		{language.French, nil, decstr.DecimalFormat{Point: ',', Group: ' ', Standard: true}},
+		{language.French, []number.Option{number.NoSeparator()}, decstr.DecimalFormat{Point: ',', Group: decstr.NoSeparator, Standard: true}},
+		{language.English, []number.Option{number.MaxFractionDigits(0)}, decstr.DecimalFormat{Point: decstr.NoSeparator, Group: ',', Standard: true}},
+		{language.MustParse("en-IN"), []number.Option{number.MinFractionDigits(2)}, decstr.DecimalFormat{Point: '.', Group: ',', Standard: false}},
+	}
+
+	for _, test := range tests {
+		got, err := FormatForOptions(test.tag, test.opts...)
+		if got != test.want || err != nil {
+			t.Errorf("FormatForOptions(%v, %d options) = (%v, %v), want %v", test.tag, len(test.opts), got, err, test.want)
+		}
+	}
+}
+
+func TestNumberOptions(t *testing.T) {
+	tests := []struct {
+		tag language.Tag
+		df  decstr.DecimalFormat
+		n   int // the number of options
+		err error
+	}{
+		{language.German, decstr.DecimalFormat{Point: ',', Group: '.', Standard: true}, 0, nil},
+		{language.German, decstr.DecimalFormat{Point: ',', Group: decstr.NoSeparator, Standard: true}, 1, nil},
+		{language.German, decstr.DecimalFormat{Point: '.', Group: ',', Standard: true}, 0, decstr.ErrMismatch},
+		{language.English, decstr.DecimalFormat{Point: '.', Group: ',', Standard: false}, 0, decstr.ErrMismatch},
+		{language.Arabic, decstr.DecimalFormat{Point: '.', Group: ',', Standard: true}, 0, decstr.ErrLanguage},
+	}
+
+	for _, test := range tests {
+		opts, err := NumberOptions(test.tag, test.df)
+		if len(opts) != test.n || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("NumberOptions(%v, %v) = (%d options, %v), want (%d options, %v)", test.tag, test.df, len(opts), err, test.n, test.err)
+		}
+	}
+}
+
+// TestNumberOptionsRoundTrip formats the numbers with x/text in the formats of decstr,
+// checks that decstr writes the same strings, and parses them back.
+func TestNumberOptionsRoundTrip(t *testing.T) {
+	tags := []string{"en", "fr", "de", "de-CH", "en-IN", "it", "es", "pt-BR", "ru", "ja", "pl", "sv"}
+	numbers := []struct {
+		value      float64
+		normalized string
+	}{
+		{-1234567.5, "-1234567.5"},
+		{1234, "1234"},
+		{0.25, "0.25"},
+		{987654321.125, "987654321.125"},
+	}
+	for _, name := range tags {
+		tag := language.MustParse(name)
+		df, err := FormatForTag(tag)
+		if err != nil {
+			t.Fatalf("FormatForTag(%v) error = %v", tag, err)
+		}
+		noGroup := df
+		noGroup.Group = decstr.NoSeparator
+		for _, df := range []decstr.DecimalFormat{df, noGroup} {
+			opts, err := NumberOptions(tag, df)
+			if err != nil {
+				t.Errorf("NumberOptions(%v, %v) error = %v", tag, df, err)
+				continue
+			}
+			p := message.NewPrinter(tag)
+			for _, n := range numbers {
+				s := p.Sprint(number.Decimal(n.value, append(opts, number.MaxFractionDigits(3))...))
+				if converted, _ := df.Convert(n.normalized); converted != replacer.Replace(s) {
+					t.Errorf("%v.Convert(%q) = %q, want %q as x/text for %v", df, n.normalized, converted, s, tag)
+				}
+				if got, err := Parse(tag, s, opts...); got != n.normalized || err != nil {
+					t.Errorf("Parse(%v, %q, %d options) = (%q, %v), want %q", tag, s, len(opts), got, err, n.normalized)
+				}
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  language.Tag
+		s    string
+		want string
+		err  error
+	}{
+		{language.German, "1.234", "1234", nil},
+		{language.English, "1.234", "1.234", nil},
+		{language.English, "1,234", "1234", nil},
+		{language.French, "0,120", "0.12", nil},
+		{language.French, "1,234.5", "", decstr.ErrMismatch},
+		{language.Arabic, "1", "", decstr.ErrLanguage},
+	}
+
+	for _, test := range tests {
+		got, err := Parse(test.tag, test.s)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Parse(%v, %q) = (%q, %v), want (%q, %v)", test.tag, test.s, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleFormatForTag() {
+	df, _ := FormatForTag(language.German)
+	s, _ := df.Convert("1234567.5")
+	fmt.Println(df, s)
+	// Output: {`,`, `.`, standard} 1.234.567,5
+}
+
+func ExampleNumberOptions() {
+	df := decstr.DecimalFormat{Point: ',', Group: decstr.NoSeparator, Standard: true}
+	opts, _ := NumberOptions(language.German, df)
+	fmt.Println(message.NewPrinter(language.German).Sprint(number.Decimal(1234567.5, opts...)))
+	// Output: 1234567,5
+}
+
+func ExampleParse() {
+	s := message.NewPrinter(language.French).Sprint(number.Decimal(1234.5))
+	normalized, _ := Parse(language.French, s)
+	fmt.Println(normalized)
+	// Output: 1234.5
+}
@@ -0,0 +1,254 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DetectFormats returns all the plausible decimal formats of a string.
+// If the format can be detected (see DetectFormat), it is the only one returned.
+// If the string is ambiguous, like "1,234" where the separator can be a grouping separator
+// or a decimal separator, both interpretations are returned, the grouping one first.
+// If the string is not a valid decimal string, it returns nil.
+// As for DetectFormat, if it is impossible to determine whether the grouping
+// is standard or non-standard, it defaults to standard.
+// Example:
+//
+//	DetectFormats("1 234,5") => [{`,`, ` `, standard}]
+//	DetectFormats("1,234")   => [{`<none>`, `,`, standard} {`,`, `<none>`, standard}]
+//	DetectFormats("1,23,4")  => nil
+func DetectFormats[T bytestr](decimal T) []DecimalFormat {
+	if df, ok := DetectFormat(decimal); ok {
+		return []DecimalFormat{df}
+	}
+	sep, ok := ambiguousSeparator(decimal)
+	if !ok {
+		return nil
+	}
+	return []DecimalFormat{
+		{Point: NoSeparator, Group: sep, Standard: true},
+		{Point: sep, Group: NoSeparator, Standard: true},
+	}
+}
+
+// DetectFormatWithHints is DetectFormat for mostly known data: if the string is ambiguous,
+// like "1,234", its interpretations (see DetectFormats) are tried against the hints in order,
+// and the first one that fits a hint is returned. An interpretation fits a hint if its
+// separators are the ones of the hint; the grouping separator may be missing.
+// It returns false if the string is not a valid decimal string, or if it is ambiguous and no hint fits it.
+// Example:
+//
+//	fr, us := {`,`, ` `, standard}, {`.`, `,`, standard}
+//	DetectFormatWithHints("1,234", fr, us)   => {`,`, `<none>`, standard}, true
+//	DetectFormatWithHints("1.234", fr, us)   => {`.`, `<none>`, standard}, true
+//	DetectFormatWithHints("1 234,5", fr, us) => {`,`, ` `, standard}, true
+func DetectFormatWithHints[T bytestr](decimal T, hints ...DecimalFormat) (df DecimalFormat, ok bool) {
+	formats := DetectFormats(decimal)
+	if len(formats) == 1 {
+		return formats[0], true
+	}
+	for _, hint := range hints {
+		for _, df := range formats {
+			if df.fits(hint) {
+				return df, true
+			}
+		}
+	}
+	return DecimalFormat{}, false
+}
+
+// fits checks if the separators used by df are the ones of the format:
+// a separator missing in df fits any separator of the format.
+func (df DecimalFormat) fits(format DecimalFormat) bool {
+	return (df.Point == NoSeparator || df.Point == format.Point) &&
+		(df.Group == NoSeparator || df.Group == format.Group)
+}
+
+// ambiguousSeparator checks if the decimal string is ambiguous, i.e. if it is
+// composed of 1 to 3 digits, a separator that can be a decimal or a grouping
+// separator, and exactly 3 digits. It returns the separator and true if it is the case.
+func ambiguousSeparator[T bytestr](decimal T) (sep rune, ok bool) {
+	_, abs := getSign(decimal)
+	n := len(abs)
+	if n < 5 || n > 7 {
+		return NoSeparator, false
+	}
+	switch abs[n-4] {
+	case ',', '.', '\'':
+		sep = rune(abs[n-4])
+	default:
+		return NoSeparator, false
+	}
+	if !isDigits(abs[:n-4]) || !isDigits(abs[n-3:]) {
+		return NoSeparator, false
+	}
+	return sep, true
+}
+
+// Candidate is a possible DecimalFormat of a decimal string,
+// with a confidence between 0 and 1 for this interpretation.
+type Candidate struct {
+	Format     DecimalFormat
+	Confidence float64
+}
+
+// groupReadingConfidence is the confidence of the grouping interpretation of an ambiguous
+// separator, like in "1,234". It reflects how common this separator is as a grouping
+// separator compared to a decimal separator.
+var groupReadingConfidence = map[rune]float64{
+	',':  0.6, // 1,234 is more common in english than 1,234 (= 1.234) in french
+	'.':  0.5, // 1.234 is as common in german as 1.234 (= 1234 / 1000) in english
+	'\'': 0.9, // ' is rarely used as a decimal separator
+}
+
+// DetectCandidates returns the same formats as DetectFormats with a confidence
+// for each of them, sorted by decreasing confidence. The confidence is based on
+// the structural evidence found in the string:
+//   - a non ambiguous string has a confidence of 1, except if it has a single grouping
+//     separator: then the standard grouping is only assumed, and the confidence is 0.9;
+//   - for ambiguous strings like "1,234" the confidences of both interpretations add up to 1
+//     and depend on how common the separator is as a grouping separator
+//     (e.g. ' is rarely a decimal separator), while a leading 0 ("0,123")
+//     is a strong evidence for the decimal interpretation.
+//
+// If the string is not a valid decimal string, it returns nil.
+func DetectCandidates[T bytestr](decimal T) []Candidate {
+	formats := DetectFormats(decimal)
+	_, abs := getSign(decimal)
+	switch len(formats) {
+	case 0:
+		return nil
+	case 1:
+		c := Candidate{Format: formats[0], Confidence: 1}
+		if c.Format.Group != NoSeparator && countGroup(abs, c.Format.Group) < 2 {
+			c.Confidence = 0.9
+		}
+		return []Candidate{c}
+	}
+	// the ambiguous case, the first format is the grouping interpretation
+	group := groupReadingConfidence[formats[0].Group]
+	if abs[0] == '0' {
+		group = 0.05
+	}
+	candidates := []Candidate{
+		{Format: formats[0], Confidence: group},
+		{Format: formats[1], Confidence: 1 - group},
+	}
+	if candidates[1].Confidence > candidates[0].Confidence {
+		candidates[0], candidates[1] = candidates[1], candidates[0]
+	}
+	return candidates
+}
+
+// countGroup counts the grouping separators group in the string or byte slice s.
+// As the detected group ' ' stands for any Unicode space (see spaceGroup), all of them are counted.
+func countGroup[T bytestr](s T, group rune) int {
+	n := 0
+	for _, r := range string(s) {
+		if spaceGroup(r) == group {
+			n++
+		}
+	}
+	return n
+}
+
+// indexGroup returns the index of the first grouping separator group in the string
+// or byte slice s (any Unicode space for ' '), or -1 if there is none.
+func indexGroup[T bytestr](s T, group rune) int {
+	return strings.IndexFunc(string(s), func(r rune) bool { return spaceGroup(r) == group })
+}
+
+// DetectFormatAll detects the common decimal format of many decimal strings
+// (e.g. the values of a CSV column). Blank values are ignored.
+// The evidence of all values is aggregated, so that ambiguous values like "1,234"
+// are resolved using the other values: if "1 234,5" is present, the ',' is a decimal separator.
+// It returns an error wrapping:
+//   - ErrInvalid if one of the values is not a valid decimal string, or if there are no values;
+//   - ErrInconsistent if the values use incompatible formats;
+//   - ErrAmbiguous if the format cannot be determined, even using all the values.
+//
+// If it is impossible to determine whether the grouping is standard or non-standard,
+// it defaults to standard.
+func DetectFormatAll[T bytestr](values []T) (DecimalFormat, error) {
+	var (
+		point, group  rune   // the detected separators
+		std, nonStd   bool   // if a standard or non-standard grouping was proven
+		ambiguous     []rune // the separators of the ambiguous values
+		count         int    // the number of non-blank values
+		conflictPoint = func(i int, r rune) error {
+			return fmt.Errorf("%w: value %d (%q) uses %q as decimal separator, but %q was found before", ErrInconsistent, i, values[i], r, point)
+		}
+		conflictGroup = func(i int, r rune) error {
+			return fmt.Errorf("%w: value %d (%q) uses %q as grouping separator, but %q was found before", ErrInconsistent, i, values[i], r, group)
+		}
+	)
+	for i, v := range values {
+		_, abs := getSign(v)
+		if len(abs) == 0 {
+			continue
+		}
+		count++
+		formats := DetectFormats(v)
+		switch len(formats) {
+		case 0:
+			return DecimalFormat{}, fmt.Errorf("%w: value %d (%q)", ErrInvalid, i, v)
+		case 2:
+			ambiguous = append(ambiguous, formats[0].Group)
+			continue
+		}
+		df := formats[0]
+		if df.Point != NoSeparator {
+			if point != NoSeparator && point != df.Point {
+				return DecimalFormat{}, conflictPoint(i, df.Point)
+			}
+			point = df.Point
+		}
+		if df.Group != NoSeparator {
+			if group != NoSeparator && group != df.Group {
+				return DecimalFormat{}, conflictGroup(i, df.Group)
+			}
+			group = df.Group
+			// the grouping style is proven only if there are at least two groups
+			if countGroup(abs, group) >= 2 {
+				std, nonStd = std || df.Standard, nonStd || !df.Standard
+			}
+		}
+	}
+	if count == 0 {
+		return DecimalFormat{}, fmt.Errorf("%w: no values", ErrInvalid)
+	}
+	if std && nonStd {
+		return DecimalFormat{}, fmt.Errorf("%w: both standard and non-standard grouping found", ErrInconsistent)
+	}
+
+	// resolve the ambiguous separators using the known ones,
+	// until nothing changes (a resolved separator may resolve others)
+	for changed := true; changed; {
+		changed = false
+		unresolved := ambiguous[:0]
+		for _, sep := range ambiguous {
+			switch {
+			case sep == point || sep == group:
+				// already known
+			case point != NoSeparator && group != NoSeparator:
+				return DecimalFormat{}, fmt.Errorf("%w: %q is used, but %q and %q are the separators", ErrInconsistent, sep, point, group)
+			case point != NoSeparator:
+				// sep is not the decimal separator, so it is the grouping one
+				group, changed = sep, true
+			case group != NoSeparator:
+				// sep is not the grouping separator, so it is the decimal one
+				point, changed = sep, true
+			default:
+				unresolved = append(unresolved, sep)
+			}
+		}
+		ambiguous = unresolved
+	}
+	if len(ambiguous) > 0 {
+		return DecimalFormat{}, fmt.Errorf("%w: %q can be a decimal or a grouping separator", ErrAmbiguous, ambiguous[0])
+	}
+	if point != NoSeparator && group != NoSeparator && !IsValidSeparatorPair(point, group) {
+		return DecimalFormat{}, fmt.Errorf("%w: %q and %q are not compatible", ErrInconsistent, point, group)
+	}
+	return DecimalFormat{Point: point, Group: group, Standard: !nonStd}, nil
+}
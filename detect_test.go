@@ -0,0 +1,185 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDetectFormats(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    []DecimalFormat
+	}{
+		{"", nil},
+		{"abc", nil},
+		{"1 234 56", nil},
+		{"123", []DecimalFormat{{Point: NoSeparator, Group: NoSeparator, Standard: true}}},
+		{"1 234,5", []DecimalFormat{{Point: ',', Group: ' ', Standard: true}}},
+		{"1,234,567", []DecimalFormat{{Point: NoSeparator, Group: ',', Standard: true}}},
+		{"1,2345", []DecimalFormat{{Point: ',', Group: NoSeparator, Standard: true}}},
+		{"1,234", []DecimalFormat{
+			{Point: NoSeparator, Group: ',', Standard: true},
+			{Point: ',', Group: NoSeparator, Standard: true},
+		}},
+		{" - 123.456 ", []DecimalFormat{
+			{Point: NoSeparator, Group: '.', Standard: true},
+			{Point: '.', Group: NoSeparator, Standard: true},
+		}},
+		{"12'345", []DecimalFormat{
+			{Point: NoSeparator, Group: '\'', Standard: true},
+			{Point: '\'', Group: NoSeparator, Standard: true},
+		}},
+		{"1234,567", []DecimalFormat{{Point: ',', Group: NoSeparator, Standard: true}}},
+		{"1 234", []DecimalFormat{{Point: NoSeparator, Group: ' ', Standard: true}}},
+	}
+
+	for _, test := range tests {
+		got := DetectFormats(test.decimal)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("DetectFormats(%q) = %v, want %v", test.decimal, got, test.want)
+		}
+	}
+}
+
+func ExampleDetectFormats() {
+	for _, df := range DetectFormats("1.234") {
+		fmt.Println(df)
+	}
+	// Output:
+	// {`<none>`, `.`, standard}
+	// {`.`, `<none>`, standard}
+}
+
+func TestDetectFormatWithHints(t *testing.T) {
+	var (
+		fr = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+		us = DecimalFormat{Point: '.', Group: ',', Standard: true}
+		de = DecimalFormat{Point: ',', Group: '.', Standard: true}
+	)
+	tests := []struct {
+		decimal string
+		hints   []DecimalFormat
+		want    DecimalFormat
+		ok      bool
+	}{
+		{"1,234", []DecimalFormat{fr, us}, DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, true},
+		{"1,234", []DecimalFormat{us, fr}, DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, true},
+		{"1.234", []DecimalFormat{fr, us}, DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, true},
+		{"1.234", []DecimalFormat{de, us}, DecimalFormat{Point: NoSeparator, Group: '.', Standard: true}, true},
+		{"1'234", []DecimalFormat{fr, us}, DecimalFormat{}, false},
+		{"1,234", nil, DecimalFormat{}, false},
+		{"1 234,5", []DecimalFormat{us}, fr, true},
+		{"abc", []DecimalFormat{fr}, DecimalFormat{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := DetectFormatWithHints(test.decimal, test.hints...)
+		if got != test.want || ok != test.ok {
+			t.Errorf("DetectFormatWithHints(%q, %v) = (%v, %v), want (%v, %v)", test.decimal, test.hints, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleDetectFormatWithHints() {
+	fr := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	us := DecimalFormat{Point: '.', Group: ',', Standard: true}
+	// mostly French data, with some US rows
+	for _, s := range []string{"1 234,5", "1,234", "1.234"} {
+		df, _ := DetectFormatWithHints(s, fr, us)
+		fmt.Println(df)
+	}
+	// Output:
+	// {`,`, ` `, standard}
+	// {`,`, `<none>`, standard}
+	// {`.`, `<none>`, standard}
+}
+
+func TestDetectCandidates(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    []Candidate
+	}{
+		{"", nil},
+		{"1,23,4", nil},
+		{"123", []Candidate{{DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, 1}}},
+		{"1 234,5", []Candidate{{DecimalFormat{Point: ',', Group: ' ', Standard: true}, 0.9}}},
+		{"1 234 567,5", []Candidate{{DecimalFormat{Point: ',', Group: ' ', Standard: true}, 1}}},
+		{"12 34 567,5", []Candidate{{DecimalFormat{Point: ',', Group: ' ', Standard: false}, 1}}},
+		{"1 234", []Candidate{{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, 0.9}}},
+		{"1 234 567", []Candidate{{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, 1}}},
+		{"1\u00A0234\u00A0567", []Candidate{{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, 1}}},
+		{"1,5", []Candidate{{DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, 1}}},
+		{"1,234", []Candidate{
+			{DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, 0.6},
+			{DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, 0.4},
+		}},
+		{"-0,234", []Candidate{
+			{DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, 0.95},
+			{DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, 0.05},
+		}},
+		{"12'345", []Candidate{
+			{DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, 0.9},
+			{DecimalFormat{Point: '\'', Group: NoSeparator, Standard: true}, 0.1},
+		}},
+	}
+
+	for _, test := range tests {
+		got := DetectCandidates(test.decimal)
+		if len(got) != len(test.want) {
+			t.Errorf("DetectCandidates(%q) = %v, want %v", test.decimal, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Format != test.want[i].Format || math.Abs(got[i].Confidence-test.want[i].Confidence) > 1e-9 {
+				t.Errorf("DetectCandidates(%q) = %v, want %v", test.decimal, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestDetectFormatAll(t *testing.T) {
+	tests := []struct {
+		values []string
+		df     DecimalFormat
+		err    error
+	}{
+		{[]string{"1 234,5", "1,234"}, DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{[]string{"1,234", "", "1 234,5"}, DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{[]string{"1,234", "2,5"}, DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{[]string{"1,234", "2,345,678"}, DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, nil},
+		{[]string{"1,234", "1.234", "1,5"}, DecimalFormat{Point: ',', Group: '.', Standard: true}, nil},
+		{[]string{"1,234", "1.234", "5"}, DecimalFormat{}, ErrAmbiguous},
+		{[]string{"1,234", "1,234,567.8"}, DecimalFormat{Point: '.', Group: ',', Standard: true}, nil},
+		{[]string{"12,34,567", "1,234.5"}, DecimalFormat{Point: '.', Group: ',', Standard: false}, nil},
+		{[]string{"12", "-3", " "}, DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{[]string{"1,234"}, DecimalFormat{}, ErrAmbiguous},
+		{[]string{"1,5", "2.5"}, DecimalFormat{}, ErrInconsistent},
+		{[]string{"1 234", "1.234.567"}, DecimalFormat{}, ErrInconsistent},
+		{[]string{"12,34,567", "1,234,567"}, DecimalFormat{}, ErrInconsistent},
+		{[]string{"1 234,5", "1.234"}, DecimalFormat{}, ErrInconsistent},
+		{[]string{"1·5", "1'234"}, DecimalFormat{}, ErrInconsistent},
+		{[]string{"1,5", "abc"}, DecimalFormat{}, ErrInvalid},
+		{[]string{" ", ""}, DecimalFormat{}, ErrInvalid},
+		{nil, DecimalFormat{}, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		df, err := DetectFormatAll(test.values)
+		if df != test.df || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("DetectFormatAll(%q) = (%v, %v), want (%v, %v)", test.values, df, err, test.df, test.err)
+		}
+	}
+}
+
+func ExampleDetectFormatAll() {
+	df, err := DetectFormatAll([]string{"1,234", "12,5", "3"})
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(df)
+	// Output: {`,`, `<none>`, standard}
+}
@@ -0,0 +1,32 @@
+package decstr
+
+import "strings"
+
+// ValidateDigits parses the decimal string (see Parse) and checks that its normalized value has
+// at most maxIntDigits digits in its integer part and maxFracDigits digits in its fractional part,
+// like the SQL NUMERIC(p, s) columns that accept p-s integer digits and s fraction digits.
+// The zero integer part, as in "0.5", has no digits, and a negative limit means no limit.
+// It returns the error of Parse, or a *DigitsError wrapping ErrTooManyDigits naming the exceeded
+// limit (the integer one is checked first).
+// Example:
+//
+//	ValidateDigits("1 234,56", 5, 2)  => nil
+//	ValidateDigits("1 234,567", 5, 2) => 3 fraction digits in "1 234,567", the limit is 2
+//	ValidateDigits("123 456", 5, 2)   => 6 integer digits in "123 456", the limit is 5
+func ValidateDigits(s string, maxIntDigits, maxFracDigits int) error {
+	normalized, _, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(normalized, "-"), ".")
+	if intPart == "0" {
+		intPart = ""
+	}
+	if maxIntDigits >= 0 && len(intPart) > maxIntDigits {
+		return &DigitsError{Decimal: s, Digits: len(intPart), Limit: maxIntDigits}
+	}
+	if maxFracDigits >= 0 && len(fracPart) > maxFracDigits {
+		return &DigitsError{Decimal: s, Fraction: true, Digits: len(fracPart), Limit: maxFracDigits}
+	}
+	return nil
+}
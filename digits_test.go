@@ -0,0 +1,55 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidateDigits(t *testing.T) {
+	tests := []struct {
+		decimal         string
+		maxInt, maxFrac int
+		err             error
+		fraction        bool
+		digits          int
+	}{
+		{"1 234,56", 5, 2, nil, false, 0},
+		{"-1 234,50", 4, 1, nil, false, 0},
+		{"0,25", 0, 2, nil, false, 0},
+		{"0", 0, 0, nil, false, 0},
+		{"123 456", 5, 2, ErrTooManyDigits, false, 6},
+		{"1 234,567", 5, 2, ErrTooManyDigits, true, 3},
+		{"123 456,789", 5, 2, ErrTooManyDigits, false, 6},
+		{"12,5", 1, -1, ErrTooManyDigits, false, 2},
+		{"1234567890,123456", -1, -1, nil, false, 0},
+		{"1,234", 5, 2, ErrAmbiguous, false, 0},
+		{"abc", 5, 2, ErrInvalid, false, 0},
+	}
+
+	for _, test := range tests {
+		err := ValidateDigits(test.decimal, test.maxInt, test.maxFrac)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ValidateDigits(%q, %d, %d) = %v, want %v", test.decimal, test.maxInt, test.maxFrac, err, test.err)
+			continue
+		}
+		if test.err != ErrTooManyDigits {
+			continue
+		}
+		var digitsErr *DigitsError
+		if !errors.As(err, &digitsErr) || digitsErr.Fraction != test.fraction || digitsErr.Digits != test.digits {
+			t.Errorf("ValidateDigits(%q, %d, %d) = %#v, want %d digits (fraction: %v)", test.decimal, test.maxInt, test.maxFrac, err, test.digits, test.fraction)
+		}
+	}
+}
+
+func ExampleValidateDigits() {
+	// a NUMERIC(7, 2) column
+	for _, amount := range []string{"12 345,67", "12 345,678", "123 456,7"} {
+		fmt.Println(ValidateDigits(amount, 5, 2))
+	}
+	// Output:
+	// <nil>
+	// decstr: too many digits: 3 fraction digits in "12 345,678", the limit is 2
+	// decstr: too many digits: 6 integer digits in "123 456,7", the limit is 5
+}
@@ -0,0 +1,88 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalid is returned when a string is not a valid (or is an ambiguous) decimal string.
+	ErrInvalid = errors.New("decstr: invalid decimal string")
+	// ErrInvalidFormat is returned when a DecimalFormat is not valid.
+	ErrInvalidFormat = errors.New("decstr: invalid decimal format")
+	// ErrMismatch is returned when a decimal string does not match a given DecimalFormat.
+	ErrMismatch = errors.New("decstr: decimal string does not match the format")
+	// ErrAmbiguous is returned when the format of decimal strings cannot be determined.
+	ErrAmbiguous = errors.New("decstr: ambiguous decimal format")
+	// ErrInconsistent is returned when decimal strings use incompatible formats.
+	ErrInconsistent = errors.New("decstr: inconsistent decimal formats")
+	// ErrNotFinite is returned when a number has no finite decimal expansion (like 1/3).
+	ErrNotFinite = errors.New("decstr: no finite decimal expansion")
+	// ErrSpecialValue is returned when a NaN or an infinity is found but not enabled (see WithSpecialValues).
+	ErrSpecialValue = errors.New("decstr: special value (NaN or infinity) not allowed")
+	// ErrTooLong is returned when a decimal string exceeds the limits set by WithMaxLength or WithMaxGroups.
+	ErrTooLong = errors.New("decstr: decimal string too long")
+	// ErrLanguage is returned when a language (or a locale, or a region) is not supported.
+	ErrLanguage = errors.New("decstr: unsupported language")
+	// ErrTooManyDigits is returned when a decimal exceeds the digit limits of ValidateDigits.
+	ErrTooManyDigits = errors.New("decstr: too many digits")
+)
+
+// SyntaxError is returned by Parse when a decimal string is invalid.
+// It reports where the detection failed, so that the input can be highlighted.
+// It wraps ErrInvalid.
+type SyntaxError struct {
+	Decimal string // the invalid decimal string
+	Offset  int    // the byte index of the offending character, or len(Decimal) if the string ends too early
+	Rune    rune   // the offending character, or utf8.RuneError if the string ends too early
+}
+
+// newSyntaxError returns the SyntaxError for the decimal string failing at the index at.
+// A negative index, for an unknown position, is reported as 0.
+func newSyntaxError[T bytestr](decimal T, at int) *SyntaxError {
+	at = max(at, 0)
+	e := &SyntaxError{Decimal: string(decimal), Offset: at, Rune: utf8.RuneError}
+	if at < len(e.Decimal) {
+		e.Rune, _ = utf8.DecodeRuneInString(e.Decimal[at:])
+	}
+	return e
+}
+
+// Error returns the description of the error, like
+// `decstr: invalid decimal string: unexpected '¸' at index 1 in "1¸5"`.
+func (e *SyntaxError) Error() string {
+	if e.Offset >= len(e.Decimal) {
+		return fmt.Sprintf("%v: unexpected end of %q", ErrInvalid, e.Decimal)
+	}
+	return fmt.Sprintf("%v: unexpected %q at index %d in %q", ErrInvalid, e.Rune, e.Offset, e.Decimal)
+}
+
+// Unwrap returns ErrInvalid.
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalid
+}
+
+// DigitsError is returned by ValidateDigits when a decimal has too many digits.
+// It names the exceeded limit. It wraps ErrTooManyDigits.
+type DigitsError struct {
+	Decimal  string // the decimal string
+	Fraction bool   // if the limit of the fractional part is exceeded, otherwise the one of the integer part
+	Digits   int    // the number of digits of the part
+	Limit    int    // the maximal number of digits of the part
+}
+
+// Error returns the description of the error, like
+// `decstr: too many digits: 7 integer digits in "1 234 567", the limit is 5`.
+func (e *DigitsError) Error() string {
+	part := "integer"
+	if e.Fraction {
+		part = "fraction"
+	}
+	return fmt.Sprintf("%v: %d %s digits in %q, the limit is %d", ErrTooManyDigits, e.Digits, part, e.Decimal, e.Limit)
+}
+
+// Unwrap returns ErrTooManyDigits.
+func (e *DigitsError) Unwrap() error {
+	return ErrTooManyDigits
+}
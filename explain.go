@@ -0,0 +1,138 @@
+package decstr
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Step is a decision taken while detecting the format of a decimal string, see ExplainDetect.
+type Step struct {
+	Offset int    // the byte offset in the string of the observed character, or of the end of the string
+	Rune   rune   // the observed character, 0 for the decisions taken at the end of the string
+	Note   string // the decision, like "grouping separator, after a group of 3 digits"
+}
+
+// String returns the description of the step, like `at 1 ',': decimal or grouping separator`.
+func (s Step) String() string {
+	if s.Rune == 0 {
+		return fmt.Sprintf("at %d (end): %s", s.Offset, s.Note)
+	}
+	return fmt.Sprintf("at %d %q: %s", s.Offset, s.Rune, s.Note)
+}
+
+// ExplainDetect detects the format of a decimal string like Detect, and also returns
+// the decisions taken on the way: the role given to each separator and why,
+// why the grouping is non-standard, and why the detection fails.
+// The last step is the result: the detected format, or the error returned by Detect
+// (and then the Report is empty).
+// It is meant to help understanding why a decimal string is rejected, not for production use.
+// Example:
+//
+//	ExplainDetect("1,234.5") steps:
+//		at 1 ',': decimal or grouping separator, as it follows 1 digit
+//		at 5 '.': another separator, so the decimal one, and ',' is the grouping separator
+//		at 7 (end): detected {`.`, `,`, standard}, normalized to "1234.5"
+func ExplainDetect(s string) (Report, []Step) {
+	e := explainer{decimal: s}
+	if _, _, ok, _ := scanDecimal(s, nil, nil, &e); !ok {
+		if _, df, ok := scanCustom(s); ok {
+			e.addEnd(len(s), "the custom format %v fits", df)
+		}
+	}
+	r, err := Detect(s)
+	if err != nil {
+		e.addEnd(len(s), "%v", err)
+		return r, e.steps
+	}
+	e.addEnd(len(s), "detected %v, normalized to %q", r.Format, r.Normalized)
+	return r, e.steps
+}
+
+// explainer records the decisions of scanDecimal on a decimal string, for ExplainDetect.
+type explainer struct {
+	decimal string // the explained decimal string
+	steps   []Step
+}
+
+// add appends a step about the character at the offset at.
+func (e *explainer) add(at int, format string, args ...any) {
+	r, _ := utf8.DecodeRuneInString(e.decimal[at:])
+	e.steps = append(e.steps, Step{Offset: at, Rune: r, Note: fmt.Sprintf(format, args...)})
+}
+
+// addEnd appends a step taken at the end of the string.
+func (e *explainer) addEnd(at int, format string, args ...any) {
+	e.steps = append(e.steps, Step{Offset: at, Note: fmt.Sprintf(format, args...)})
+}
+
+// reject appends a step explaining the rejection of the character at the offset at.
+func (e *explainer) reject(at int, format string, args ...any) {
+	e.add(at, "rejected: "+format, args...)
+}
+
+// rejectEnd appends a step explaining a rejection at the end of the string.
+func (e *explainer) rejectEnd(at int, format string, args ...any) {
+	e.addEnd(at, "rejected: "+format, args...)
+}
+
+// firstSeparator explains the role of the first separator, that follows before digits.
+func (e *explainer) firstSeparator(at, before int, isPoint bool) {
+	if isPoint {
+		e.add(at, "decimal separator, as it follows %s (not 1 to 3)", digitCount(before))
+	} else {
+		e.add(at, "decimal or grouping separator, as it follows %s", digitCount(before))
+	}
+}
+
+// afterPoint explains the rejection of a character after the decimal separator point.
+func (e *explainer) afterPoint(at int, point rune) {
+	if !e.separatorAt(at) {
+		e.reject(at, "not a digit nor a separator")
+		return
+	}
+	e.reject(at, "no separator is allowed after the decimal separator %q", point)
+}
+
+// badGroup explains the rejection of a repeated separator after a group of before digits,
+// when the previous groups have mode digits (0 for the first group).
+func (e *explainer) badGroup(at, before, mode int) {
+	if before != 2 && before != 3 {
+		e.reject(at, "group of %s, want 3 (or 2 before the last group)", digitCount(before))
+		return
+	}
+	e.reject(at, "group of %s, but the previous group has %s", digitCount(before), digitCount(mode))
+}
+
+// badPoint explains the rejection of a new separator as the decimal separator,
+// after a group of before digits, with the grouping separator group.
+func (e *explainer) badPoint(at, before int, group rune) {
+	point, _ := utf8.DecodeRuneInString(e.decimal[at:])
+	switch {
+	case !IsValidSeparatorPair(point, group) && !e.separatorAt(at):
+		e.reject(at, "not a digit nor a separator")
+	case before != 3:
+		e.reject(at, "the decimal separator follows a group of %s, want 3", digitCount(before))
+	default:
+		e.reject(at, "%q cannot be the decimal separator with the grouping separator %q", point, group)
+	}
+}
+
+// separatorAt checks if the character at the offset at is one of the separators known by the detection.
+func (e *explainer) separatorAt(at int) bool {
+	r, _ := utf8.DecodeRuneInString(e.decimal[at:])
+	switch {
+	case r < utf8.RuneSelf:
+		return byteClass[r] == classSeparator || byteClass[r] == classGroup
+	case r == '·':
+		return true
+	}
+	return isUnicodeSpace(r)
+}
+
+// digitCount returns the number of digits n in words, like "1 digit" or "3 digits".
+func digitCount(n int) string {
+	if n == 1 {
+		return "1 digit"
+	}
+	return fmt.Sprintf("%d digits", n)
+}
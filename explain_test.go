@@ -0,0 +1,80 @@
+package decstr
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestExplainDetect(t *testing.T) {
+	tests := []struct {
+		s     string
+		steps []string // the notes of the steps before the result, by prefix
+		last  string   // the result, by prefix
+	}{
+		{"12", nil, "detected {`<none>`, `<none>`, standard}"},
+		{"1,234.5", []string{"decimal or grouping separator, as it follows 1 digit", "another separator, so the decimal one"}, "detected {`.`, `,`, standard}"},
+		{"12,34,567.8", []string{"decimal or grouping", "repeated separator, so a grouping separator, after a group of 2 digits", "another separator", "non-standard grouping"}, "detected {`.`, `,`, non-standard}"},
+		{"1,23", []string{"decimal or grouping", "the only separator ',' is the decimal one"}, "detected {`,`, `<none>`, standard}"},
+		{"-12345,6", []string{"decimal separator, as it follows 5 digits"}, "detected"},
+		{"1 234", []string{"grouping separator (a space"}, "detected {`<none>`, ` `, standard}"},
+		{"1,234", []string{"decimal or grouping", "rejected: ambiguous"}, "decstr: ambiguous"},
+		{"1.2.3", []string{"decimal or grouping", "rejected: group of 1 digit, want 3"}, "decstr: invalid"},
+		{"1 234 56", []string{"grouping", "repeated", "rejected: the last group has 2 digits"}, "decstr: invalid"},
+		{"1,234,56,789", []string{"decimal or grouping", "repeated", "rejected: group of 2 digits, but the previous group has 3 digits"}, "decstr: invalid"},
+		{"1234 567", []string{"rejected: grouping separator after 4 digits"}, "decstr: invalid"},
+		{"1.234,5,6", []string{"decimal or grouping", "another", "rejected: no separator is allowed after the decimal separator ','"}, "decstr: invalid"},
+		{"12,34.5", []string{"decimal or grouping", "rejected: the decimal separator follows a group of 2 digits"}, "decstr: invalid"},
+		{"1 234'5", []string{"grouping", "rejected: '\\'' cannot be the decimal separator"}, "decstr: invalid"},
+		{"12 kg", []string{"grouping", "rejected: not a digit nor a separator"}, "decstr: invalid"},
+		{"€12", []string{"rejected: not a digit nor a separator"}, "decstr: invalid"},
+		{"-", []string{"rejected: no digit"}, "decstr: invalid"},
+	}
+
+	for _, test := range tests {
+		r, steps := ExplainDetect(test.s)
+		if len(steps) != len(test.steps)+1 {
+			t.Errorf("ExplainDetect(%q) steps = %v, want %d steps", test.s, steps, len(test.steps)+1)
+			continue
+		}
+		for i, note := range test.steps {
+			if !strings.HasPrefix(steps[i].Note, note) {
+				t.Errorf("ExplainDetect(%q) step %d = %v, want %q", test.s, i, steps[i], note)
+			}
+		}
+		if last := steps[len(steps)-1]; !strings.HasPrefix(last.Note, test.last) || last.Offset != len(test.s) {
+			t.Errorf("ExplainDetect(%q) last step = %v, want %q", test.s, last, test.last)
+		}
+		if want, _ := Detect(test.s); r.Normalized != want.Normalized || r.Format != want.Format {
+			t.Errorf("ExplainDetect(%q) report = %+v, want %+v", test.s, r, want)
+		}
+	}
+
+	// a rejection is always explained
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, df := range ListFormats() {
+		for i := range 200 {
+			s := Generate(df, WithRand(rnd), WithNearMiss())
+			if i%2 == 0 {
+				s = Generate(df, WithRand(rnd))
+			}
+			_, steps := ExplainDetect(s)
+			if _, err := Detect(s); err != nil && (len(steps) < 2 || !strings.HasPrefix(steps[len(steps)-2].Note, "rejected: ")) {
+				t.Errorf("ExplainDetect(%q) = %v, want a rejection before %v", s, steps, err)
+			}
+		}
+	}
+}
+
+func ExampleExplainDetect() {
+	_, steps := ExplainDetect("1.234,5,6")
+	for _, step := range steps {
+		fmt.Println(step)
+	}
+	// Output:
+	// at 1 '.': decimal or grouping separator, as it follows 1 digit
+	// at 5 ',': another separator, so the decimal one, and '.' is the grouping separator
+	// at 7 ',': rejected: no separator is allowed after the decimal separator ','
+	// at 9 (end): decstr: invalid decimal string: unexpected ',' at index 7 in "1.234,5,6"
+}
@@ -0,0 +1,54 @@
+package decstr
+
+// Formatter converts decimals to a DecimalFormat, like DecimalFormat.Convert without options,
+// but with the format validated and its separators encoded once, in Compile.
+// It is safe for concurrent use.
+type Formatter struct {
+	df  DecimalFormat
+	err error // the error returned by Validate
+	sep separators
+}
+
+// Compile returns a Formatter for the DecimalFormat, to convert many decimals to it.
+// If the DecimalFormat is not valid, the conversions of the Formatter always fail,
+// and Err returns the error.
+// Example:
+//
+//	f := DecimalFormat{Point: ',', Group: ' ', Standard: true}.Compile()
+//	f.Format("-1234567.891") => "-1 234 567,891", true
+func (df DecimalFormat) Compile() *Formatter {
+	return &Formatter{df: df, err: df.Validate(), sep: encodeSeparators(df)}
+}
+
+// Format returns the decimal converted to the format, like DecimalFormat.Convert without options.
+// If the input is not a valid decimal string, or has a fractional part but the format has
+// no decimal separator, or if the format is not valid, it returns "0" and false.
+func (f *Formatter) Format(decimal string) (string, bool) {
+	if f.err != nil {
+		return "0", false
+	}
+	return convertString(f.df, &f.sep, decimal, nil)
+}
+
+// AppendFormat appends the decimal converted to the format to dst and returns the extended buffer,
+// like DecimalFormat.AppendConvert. If the conversion fails (see Format), it returns dst unchanged and false.
+func (f *Formatter) AppendFormat(dst []byte, decimal []byte) ([]byte, bool) {
+	if f.err != nil {
+		return dst, false
+	}
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return dst, false
+	}
+	return appendSeparated(dst, &f.sep, normalized)
+}
+
+// DecimalFormat returns the format of the Formatter.
+func (f *Formatter) DecimalFormat() DecimalFormat {
+	return f.df
+}
+
+// Err returns the error of the validation of the format, nil if it is valid.
+func (f *Formatter) Err() error {
+	return f.err
+}
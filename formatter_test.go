@@ -0,0 +1,79 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestFormatter(t *testing.T) {
+	decimals := []string{
+		"0", "-1", "12", "123", "1234", "-12345", "123456", "1234567.891", "-0.5", "1 234,5",
+		"12,34,567.8", "1,234", "abc", "", "1.5",
+	}
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, df := range ListFormats() {
+		for range 20 {
+			decimals = append(decimals, Generate(df, WithRand(rnd)))
+		}
+	}
+
+	formats := append(ListFormats(), DecimalFormat{Point: ',', Group: ','}, DecimalFormat{Point: 'x'})
+	for _, df := range formats {
+		f := df.Compile()
+		if err := df.Validate(); f.DecimalFormat() != df || (f.Err() == nil) != (err == nil) || (err != nil && !errors.Is(f.Err(), ErrInvalidFormat)) {
+			t.Errorf("(%v).Compile() = (%v, %v), want (%v, %v)", df, f.DecimalFormat(), f.Err(), df, df.Validate())
+		}
+		for _, decimal := range decimals {
+			want, wantOK := df.Convert(decimal)
+			if got, ok := f.Format(decimal); got != want || ok != wantOK {
+				t.Errorf("(%v).Compile().Format(%q) = (%q, %v), want (%q, %v)", df, decimal, got, ok, want, wantOK)
+			}
+			wantBuf, _ := df.AppendConvert([]byte("x"), []byte(decimal))
+			if got, ok := f.AppendFormat([]byte("x"), []byte(decimal)); string(got) != string(wantBuf) || ok != wantOK {
+				t.Errorf("(%v).Compile().AppendFormat(%q) = (%q, %v), want (%q, %v)", df, decimal, got, ok, wantBuf, wantOK)
+			}
+		}
+	}
+}
+
+func TestFormatterAllocs(t *testing.T) {
+	f := DecimalFormat{Point: ',', Group: ' ', Standard: true}.Compile()
+	decimal := []byte("-1234567.891")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = f.AppendFormat(buf[:0], decimal)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendFormat allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkFormatterFormat(b *testing.B) {
+	f := DecimalFormat{Point: ',', Group: ' ', Standard: true}.Compile()
+	for i := 0; i < b.N; i++ {
+		f.Format("-1234567.891")
+	}
+}
+
+func BenchmarkFormatterAppendFormat(b *testing.B) {
+	f := DecimalFormat{Point: ',', Group: ' ', Standard: true}.Compile()
+	decimal := []byte("-1234567.891")
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, _ = f.AppendFormat(buf[:0], decimal)
+	}
+}
+
+func ExampleDecimalFormat_Compile() {
+	f := DecimalFormat{Point: ',', Group: '.', Standard: true}.Compile()
+	for _, v := range []string{"1234567.89", "-0.5", "42"} {
+		formatted, _ := f.Format(v)
+		fmt.Println(formatted)
+	}
+	// Output:
+	// 1.234.567,89
+	// -0,5
+	// 42
+}
@@ -0,0 +1,116 @@
+package decstr
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// vulgarFractions maps the unicode vulgar fractions to their numerator and denominator.
+var vulgarFractions = map[rune][2]string{
+	'½': {"1", "2"}, '⅓': {"1", "3"}, '⅔': {"2", "3"}, '¼': {"1", "4"}, '¾': {"3", "4"},
+	'⅕': {"1", "5"}, '⅖': {"2", "5"}, '⅗': {"3", "5"}, '⅘': {"4", "5"}, '⅙': {"1", "6"},
+	'⅚': {"5", "6"}, '⅐': {"1", "7"}, '⅛': {"1", "8"}, '⅜': {"3", "8"}, '⅝': {"5", "8"},
+	'⅞': {"7", "8"}, '⅑': {"1", "9"}, '⅒': {"1", "10"}, '↉': {"0", "3"},
+}
+
+// ParseFraction parses a fraction and returns its normalized decimal value.
+// The accepted forms are (with an optional sign):
+//   - unicode vulgar fractions, optionally preceded by an integer: "½", "1¾", "1 ¾";
+//   - ASCII fractions, optionally preceded by an integer: "3/4", "1 1/2"
+//     (the fraction slash '⁄' can be used instead of '/');
+//   - ordinary decimal strings, like "1,5" (see NormalizeCheck).
+//
+// It returns an error wrapping ErrNotFinite if the fraction has no finite
+// decimal expansion (like "1/3"), and an error wrapping ErrInvalid if the string is not valid.
+// Example:
+//
+//	ParseFraction("1 1/2") => "1.5", nil
+//	ParseFraction("-¾")    => "-0.75", nil
+//	ParseFraction("2/3")   => "", ErrNotFinite
+func ParseFraction(s string) (string, error) {
+	sign, abs := getSign(s)
+	var whole, num, den string
+	if r, n := utf8.DecodeLastRuneInString(abs); vulgarFractions[r][1] != "" {
+		whole = strings.TrimRight(abs[:len(abs)-n], " ")
+		num, den = vulgarFractions[r][0], vulgarFractions[r][1]
+	} else if before, after, ok := cutFractionSlash(abs); ok {
+		den = strings.TrimLeft(after, " ")
+		num = strings.TrimRight(before, " ")
+		if i := strings.LastIndexByte(num, ' '); i >= 0 {
+			whole, num = strings.TrimRight(num[:i], " "), num[i+1:]
+		}
+		if num == "" || den == "" {
+			return "", fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+	} else if normalized, ok := NormalizeCheck(s); ok {
+		return normalized, nil
+	} else {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	if !isDigits(whole) || !isDigits(num) || !isDigits(den) {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	value, err := fractionToDecimal(whole, num, den)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", err, s)
+	}
+	if value == "0" {
+		return value, nil
+	}
+	return sign + value, nil
+}
+
+// cutFractionSlash slices s around the first '/' or '⁄'.
+func cutFractionSlash(s string) (before, after string, found bool) {
+	if before, after, found = strings.Cut(s, "/"); found {
+		return before, after, found
+	}
+	return strings.Cut(s, "⁄")
+}
+
+// fractionToDecimal returns the normalized decimal value of whole + num/den,
+// where all the strings are made of ASCII digits (whole can be empty).
+func fractionToDecimal(whole, num, den string) (string, error) {
+	var w, n, d big.Int
+	if whole != "" {
+		w.SetString(whole, 10)
+	}
+	n.SetString(num, 10)
+	d.SetString(den, 10)
+	if d.Sign() == 0 {
+		return "", ErrInvalid
+	}
+	// reduce the fraction, the denominator should be of the form 2^a 5^b
+	var g big.Int
+	g.GCD(nil, nil, &n, &d)
+	if g.Sign() != 0 {
+		n.Quo(&n, &g)
+		d.Quo(&d, &g)
+	}
+	var a, b int // the powers of 2 and 5 in d
+	rest := new(big.Int).Set(&d)
+	for two, five, m := big.NewInt(2), big.NewInt(5), new(big.Int); ; {
+		if m.Mod(rest, two).Sign() == 0 {
+			rest.Quo(rest, two)
+			a++
+		} else if m.Mod(rest, five).Sign() == 0 {
+			rest.Quo(rest, five)
+			b++
+		} else {
+			break
+		}
+	}
+	if rest.Cmp(big.NewInt(1)) != 0 {
+		return "", ErrNotFinite
+	}
+	// whole + n/d = (whole*10^k + n*10^k/d) / 10^k with k = max(a, b)
+	k := max(a, b)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(k)), nil)
+	n.Mul(&n, scale)
+	n.Quo(&n, &d)
+	w.Mul(&w, scale)
+	n.Add(&n, &w)
+	return shiftPoint(n.String(), -k), nil
+}
@@ -0,0 +1,62 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseFraction(t *testing.T) {
+	tests := []struct {
+		s     string
+		value string
+		err   error
+	}{
+		{"", "", ErrInvalid},
+		{"abc", "", ErrInvalid},
+		{"½", "0.5", nil},
+		{"-¾", "-0.75", nil},
+		{"1½", "1.5", nil},
+		{"2 ⅜", "2.375", nil},
+		{"⅒", "0.1", nil},
+		{"↉", "0", nil},
+		{"3/4", "0.75", nil},
+		{" - 1 1/2 ", "-1.5", nil},
+		{"12 3/8", "12.375", nil},
+		{"1⁄8", "0.125", nil},
+		{"6/3", "2", nil},
+		{"7/20", "0.35", nil},
+		{"0/5", "0", nil},
+		{"-0/5", "0", nil},
+		{"1,5", "1.5", nil},
+		{"⅓", "", ErrNotFinite},
+		{"1 2/3", "", ErrNotFinite},
+		{"1/0", "", ErrInvalid},
+		{"1/", "", ErrInvalid},
+		{"/2", "", ErrInvalid},
+		{"1.5/2", "", ErrInvalid},
+		{"a½", "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		value, err := ParseFraction(test.s)
+		if value != test.value || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ParseFraction(%q) = (%q, %v), want (%q, %v)", test.s, value, err, test.value, test.err)
+		}
+	}
+}
+
+func ExampleParseFraction() {
+	for _, s := range []string{"1 1/2", "¾", "1/3"} {
+		value, err := ParseFraction(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(value)
+	}
+	// Output:
+	// 1.5
+	// 0.75
+	// decstr: no finite decimal expansion: "1/3"
+}
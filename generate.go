@@ -0,0 +1,125 @@
+package decstr
+
+import (
+	"math/rand/v2"
+	"strings"
+)
+
+// Generate returns a random decimal string written in the DecimalFormat, for property testing:
+// it has up to 12 integer digits, grouped like the format (in groups of 3, or of 2 after the
+// first group of 3 if the format is not standard), may be negative, and has up to 6 fraction
+// digits (possibly with trailing zeros) if the format has a decimal separator.
+// Note that the result may be ambiguous, like "1,234", so it has to be read in its format.
+// With WithNearMiss, the result is a string that is not valid in the format,
+// obtained by one edit of a valid one, like "1 23 456,5", "1 234,,5" or "-12O,5".
+// The source of randomness can be set with WithRand, to get reproducible results.
+// If the DecimalFormat is not valid, Generate returns an empty string.
+func Generate(df DecimalFormat, opts ...Option) string {
+	if df.Validate() != nil {
+		return ""
+	}
+	o := newOptions(opts)
+	if !o.nearMiss {
+		return generateValid(df, o)
+	}
+	for {
+		if s := nearMiss(generateValid(df, o), df, o); s != "" {
+			return s
+		}
+	}
+}
+
+// intN returns a random int in [0, n) from the source of the options.
+func (o *options) intN(n int) int {
+	if o.rand == nil {
+		return rand.IntN(n)
+	}
+	return o.rand.IntN(n)
+}
+
+// generateValid returns a random decimal string written in the DecimalFormat.
+func generateValid(df DecimalFormat, o *options) string {
+	var sb strings.Builder
+	// the integer digits, without leading zero
+	digits := make([]byte, 1+o.intN(12))
+	for i := range digits {
+		digits[i] = byte('0' + o.intN(10))
+	}
+	if len(digits) > 1 {
+		digits[0] = byte('1' + o.intN(9))
+	}
+	if df.Group == NoSeparator {
+		sb.Write(digits)
+	} else {
+		// the sizes of the groups, from the right
+		sizes := []int{min(3, len(digits))}
+		size := 3
+		if !df.Standard {
+			size = 2
+		}
+		for n := len(digits) - 3; n > 0; n -= size {
+			sizes = append(sizes, min(size, n))
+		}
+		for i, n := len(sizes)-1, 0; i >= 0; i-- {
+			if n > 0 {
+				sb.WriteRune(df.Group)
+			}
+			sb.Write(digits[n : n+sizes[i]])
+			n += sizes[i]
+		}
+	}
+	if df.Point != NoSeparator && o.intN(2) == 0 {
+		sb.WriteRune(df.Point)
+		for n := 1 + o.intN(6); n > 0; n-- {
+			sb.WriteByte(byte('0' + o.intN(10)))
+		}
+	}
+	// a quarter of the non-zero decimals are negative
+	if o.intN(4) == 0 && strings.ContainsAny(sb.String(), "123456789") {
+		return "-" + sb.String()
+	}
+	return sb.String()
+}
+
+// nearMissRunes are the runes inserted by nearMiss, in addition to the separators of the format.
+var nearMissRunes = []rune{',', '.', ' ', '\'', '-', '+', 'O', 'l'}
+
+// nearMiss applies a random edit to the valid decimal string: it inserts, deletes, duplicates
+// or replaces a rune. It returns the edited string if it is not valid in the format,
+// or an empty string.
+func nearMiss(valid string, df DecimalFormat, o *options) string {
+	runes := []rune(valid)
+	i := o.intN(len(runes))
+	insert := nearMissRunes[o.intN(len(nearMissRunes))]
+	if o.intN(2) == 0 {
+		// prefer the separators of the format
+		insert = [2]rune{df.Point, df.Group}[o.intN(2)]
+		if insert == NoSeparator {
+			insert = ' '
+		}
+	}
+	switch o.intN(4) {
+	case 0:
+		runes = append(runes[:i], append([]rune{insert}, runes[i:]...)...)
+	case 1:
+		runes = append(runes[:i], runes[i+1:]...)
+	case 2:
+		runes = append(runes[:i], append([]rune{runes[i]}, runes[i:]...)...)
+	default:
+		runes[i] = insert
+	}
+	if s := string(runes); !validIn(df, s) {
+		return s
+	}
+	return ""
+}
+
+// validIn checks if the decimal string is valid in the format (see parseIn):
+// if the format has a standard grouping, the non-standard one is not valid.
+func validIn(df DecimalFormat, decimal string) bool {
+	if _, err := parseIn(df, decimal, nil); err != nil {
+		return false
+	}
+	_, got, ok := detectAndNormalize(decimal)
+	return !ok || got.Standard || !df.Standard
+}
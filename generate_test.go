@@ -0,0 +1,68 @@
+package decstr
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	formats := []DecimalFormat{
+		{Point: ',', Group: ' ', Standard: true},
+		{Point: '.', Group: ',', Standard: true},
+		{Point: '.', Group: ',', Standard: false},
+		{Point: ',', Group: '.', Standard: true},
+		{Point: '.', Group: '\'', Standard: true},
+		{Point: '·', Group: ',', Standard: true},
+		{Point: ',', Group: NoSeparator, Standard: true},
+		{Point: NoSeparator, Group: ' ', Standard: true},
+		{Point: NoSeparator, Group: NoSeparator, Standard: true},
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, df := range formats {
+		for range 1000 {
+			s := Generate(df, WithRand(r))
+			if !validIn(df, s) {
+				t.Fatalf("Generate(%v) = %q, not valid in the format", df, s)
+			}
+			normalized, _ := parseIn(df, s, nil)
+			if digits := strings.Map(func(r rune) rune {
+				if r == df.Group {
+					return -1
+				}
+				return r
+			}, s); strings.TrimRight(strings.Replace(digits, string(df.Point), ".", 1), "0.") != strings.TrimRight(normalized, "0.") {
+				t.Fatalf("Generate(%v) = %q, normalized to %q", df, s, normalized)
+			}
+			if s := Generate(df, WithRand(r), WithNearMiss()); validIn(df, s) {
+				t.Fatalf("Generate(%v, WithNearMiss()) = %q, valid in the format", df, s)
+			}
+		}
+	}
+
+	// the results are reproducible
+	df := formats[0]
+	a := Generate(df, WithRand(rand.New(rand.NewPCG(7, 7))))
+	b := Generate(df, WithRand(rand.New(rand.NewPCG(7, 7))))
+	if a != b {
+		t.Errorf("Generate with the same seed = %q and %q, want the same", a, b)
+	}
+	if s := Generate(DecimalFormat{Point: ',', Group: ','}); s != "" {
+		t.Errorf("Generate with an invalid format = %q, want \"\"", s)
+	}
+}
+
+func ExampleGenerate() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 3 {
+		valid, invalid := Generate(df, WithRand(r)), Generate(df, WithRand(r), WithNearMiss())
+		fmt.Printf("%q is valid, %q is not\n", valid, invalid)
+	}
+	// Output:
+	// "5.772.044.116" is valid, "-456.506.961.398,,6" is not
+	// "82.159.894.547,85" is valid, "1.837.053.1.2" is not
+	// "-9.569,877" is valid, "-10.27l3" is not
+}
@@ -1,3 +1,5 @@
 module github.com/kpym/decstr
 
 go 1.22.5
+
+require golang.org/x/text v0.22.0
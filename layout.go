@@ -0,0 +1,83 @@
+package decstr
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// decorates checks if the options change the output of Convert for the regular numbers.
+func (o *options) decorates() bool {
+	return o.positiveSign != 0 || o.width > 0 || o.minScale > 0 || o.sigFigs > 0 || o.accounting || o.currency != "" || o.si
+}
+
+// prepare applies the options that change the digits written by Convert
+// to the normalized decimal: the significant figures, the accounting rounding and the minimal scale.
+func (o *options) prepare(df DecimalFormat, normalized string) string {
+	if o.sigFigs > 0 {
+		normalized = roundSig(normalized, o.sigFigs, o.sigMode)
+	}
+	if df.Point == NoSeparator {
+		if o.accounting {
+			normalized = roundFrac(normalized, 0)
+		}
+		return normalized
+	}
+	scale := o.minScale
+	if o.sigFigs > 0 {
+		scale = max(scale, sigScale(normalized, o.sigFigs))
+	}
+	if o.accounting {
+		normalized = roundFrac(normalized, 2)
+		scale = max(scale, 2)
+	}
+	if scale > 0 {
+		normalized = padScale(normalized, scale)
+	}
+	return normalized
+}
+
+// layout applies the output options to the formatted decimal:
+// the sign of the positive numbers (or the accounting parentheses),
+// then the padding to the width, and finally the currency symbol.
+// The result may share the memory of formatted.
+func (o *options) layout(formatted []byte) []byte {
+	switch {
+	case o.accounting && formatted[0] == '-':
+		formatted = append(append([]byte{'('}, formatted[1:]...), ')')
+	case o.accounting:
+		formatted = append(formatted, ' ')
+	case o.positiveSign != 0 && formatted[0] != '-':
+		formatted = append([]byte{o.positiveSign}, formatted...)
+	}
+	width := o.width
+	if o.currency != "" {
+		width -= utf8.RuneCountInString(o.currency) + 1
+	}
+	if pad := width - utf8.RuneCount(formatted); pad > 0 {
+		formatted = o.pad(formatted, pad)
+	}
+	if o.currency != "" {
+		formatted = append(append([]byte(o.currency), ' '), formatted...)
+	}
+	return formatted
+}
+
+// pad adds pad spaces or zeros to the formatted decimal, as set by the options.
+func (o *options) pad(formatted []byte, pad int) []byte {
+	switch {
+	case o.leftAlign:
+		return append(formatted, bytes.Repeat([]byte{' '}, pad)...)
+	case o.zeroPadding:
+		// the zeros go between the sign and the digits
+		sign := 0
+		if formatted[0] == '-' || formatted[0] == '+' || formatted[0] == ' ' || formatted[0] == '(' {
+			sign = 1
+		}
+		padded := make([]byte, 0, len(formatted)+pad)
+		padded = append(padded, formatted[:sign]...)
+		padded = append(padded, bytes.Repeat([]byte{'0'}, pad)...)
+		return append(padded, formatted[sign:]...)
+	default:
+		return append(bytes.Repeat([]byte{' '}, pad), formatted...)
+	}
+}
@@ -0,0 +1,114 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConvertLayout(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	tests := []struct {
+		decimal string
+		opts    []Option
+		want    string
+	}{
+		{"1234.56", []Option{WithPlusSign()}, "+1 234,56"},
+		{"+1234.56", []Option{WithPlusSign()}, "+1 234,56"},
+		{"-1234.56", []Option{WithPlusSign()}, "-1 234,56"},
+		{"0", []Option{WithPlusSign()}, "+0"},
+		{"12", []Option{WithSpaceSign()}, " 12"},
+		{"-12", []Option{WithSpaceSign()}, "-12"},
+		{"12", []Option{WithSpaceSign(), WithPlusSign()}, "+12"},
+		{"12", []Option{WithSpecialValues()}, "12"},
+		{"1234.5", []Option{WithWidth(10)}, "   1 234,5"},
+		{"1234.5", []Option{WithWidth(10), WithLeftAlign()}, "1 234,5   "},
+		{"-1234.5", []Option{WithWidth(10), WithZeroPadding()}, "-001 234,5"},
+		{"1234.5", []Option{WithWidth(10), WithZeroPadding(), WithPlusSign()}, "+001 234,5"},
+		{"1234.5", []Option{WithWidth(10), WithZeroPadding(), WithLeftAlign()}, "1 234,5   "},
+		{"1234567.5", []Option{WithWidth(5)}, "1 234 567,5"},
+		{"-5", []Option{WithWidth(4)}, "  -5"},
+		{"1.5", []Option{WithMinScale(2)}, "1,50"},
+		{"12", []Option{WithMinScale(2)}, "12,00"},
+		{"0.125", []Option{WithMinScale(2)}, "0,125"},
+		{"1234.5", []Option{WithMinScale(2), WithWidth(10)}, "  1 234,50"},
+		{"1.5", []Option{WithSignificantFigures(3, HalfAwayFromZero)}, "1,50"},
+		{"1234.5", []Option{WithSignificantFigures(2, HalfAwayFromZero)}, "1 200"},
+		{"0.0012345", []Option{WithSignificantFigures(3, HalfEven)}, "0,00123"},
+		{"0.0012355", []Option{WithSignificantFigures(4, HalfEven)}, "0,001236"},
+		{"-9.96", []Option{WithSignificantFigures(2, HalfAwayFromZero)}, "-10"},
+		{"0.0996", []Option{WithSignificantFigures(2, HalfAwayFromZero)}, "0,10"},
+		{"0", []Option{WithSignificantFigures(3, HalfAwayFromZero)}, "0,00"},
+		{"1.5", []Option{WithSignificantFigures(3, TowardZero), WithMinScale(4)}, "1,5000"},
+		{"1234.5", []Option{WithAccounting()}, "1 234,50 "},
+		{"-1234.555", []Option{WithAccounting()}, "(1 234,56)"},
+		{"-0.001", []Option{WithAccounting()}, "0,00 "},
+		{"12", []Option{WithAccounting(), WithPlusSign()}, "12,00 "},
+		{"0.5", []Option{WithAccounting(), WithMinScale(3)}, "0,500 "},
+		{"-12", []Option{WithAccounting(), WithWidth(10), WithZeroPadding()}, "(00012,00)"},
+		{"-12", []Option{WithCurrencySymbol("€")}, "€ -12"},
+		{"-12", []Option{WithAccounting(), WithCurrencySymbol("€"), WithWidth(12)}, "€    (12,00)"},
+		{"1234567", []Option{WithAccounting(), WithCurrencySymbol("CHF"), WithWidth(12)}, "CHF 1 234 567,00 "},
+	}
+
+	for _, test := range tests {
+		got, ok := df.Convert(test.decimal, test.opts...)
+		if got != test.want || !ok {
+			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, true)", df, test.decimal, got, ok, test.want)
+		}
+		bgot, ok := Convert(df, []byte(test.decimal), test.opts...)
+		if string(bgot) != test.want || !ok {
+			t.Errorf("Convert(%v, []byte(%q)) = (%q, %v), want (%q, true)", df, test.decimal, bgot, ok, test.want)
+		}
+	}
+}
+
+func TestConvertWidthRunes(t *testing.T) {
+	df := DecimalFormat{Point: '·', Group: ',', Standard: true}
+	got, _ := df.Convert("1234.5", WithWidth(9))
+	if want := "  1,234·5"; got != want {
+		t.Errorf("(%v).Convert(%q, WithWidth(9)) = %q, want %q", df, "1234.5", got, want)
+	}
+}
+
+func ExampleWithWidth() {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	for _, amount := range []string{"1234.5", "-12.25", "1234567"} {
+		s, _ := df.Convert(amount, WithWidth(12))
+		fmt.Printf("|%s|\n", s)
+	}
+	// Output:
+	// |     1 234,5|
+	// |      -12,25|
+	// |   1 234 567|
+}
+
+func TestConvertAccountingNoSeparator(t *testing.T) {
+	df := DecimalFormat{Point: NoSeparator, Group: ','}
+	got, _ := df.Convert("-1234.5", WithAccounting())
+	if want := "(1,235)"; got != want {
+		t.Errorf("(%v).Convert(%q, WithAccounting()) = %q, want %q", df, "-1234.5", got, want)
+	}
+}
+
+func ExampleWithAccounting() {
+	df := DecimalFormat{Point: '.', Group: ',', Standard: true}
+	for _, amount := range []string{"1234.5", "-87.125", "1250000"} {
+		s, _ := df.Convert(amount, WithAccounting(), WithCurrencySymbol("$"), WithWidth(16))
+		fmt.Printf("|%s|\n", s)
+	}
+	// Output:
+	// |$      1,234.50 |
+	// |$        (87.13)|
+	// |$  1,250,000.00 |
+}
+
+func ExampleWithPlusSign() {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	for _, delta := range []string{"1234.56", "-12.5"} {
+		s, _ := df.Convert(delta, WithPlusSign())
+		fmt.Println(s)
+	}
+	// Output:
+	// +1 234,56
+	// -12,5
+}
@@ -0,0 +1,99 @@
+package decstr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// GoLiteral is the DecimalFormat producing valid Go number literals, like "1_234_567.89".
+// The same literals are valid in many other programming languages (Python, Rust, Java, JavaScript…).
+var GoLiteral = DecimalFormat{Point: '.', Group: '_', Standard: true}
+
+// maxLiteralExponent is the maximal absolute value of the exponent accepted by ParseLiteral.
+const maxLiteralExponent = 1000
+
+// ParseLiteral parses a programming language decimal literal and returns its exact
+// normalized value. The literal:
+//   - may start with a '-' or a '+' sign;
+//   - has digits, optionally separated by single underscores, with at most one '.',
+//     like "1_000.000_1", ".5" or "1.";
+//   - may end with an exponent: 'e' or 'E', an optional sign and digits (e.g. "1.5e-3").
+//
+// Underscores are only allowed between digits, and the absolute value of the exponent
+// cannot exceed 1000. Otherwise it returns an error wrapping ErrInvalid.
+// Example:
+//
+//	ParseLiteral("1_000.000_1") => "1000.0001", nil
+//	ParseLiteral("-1.25e2")     => "-125", nil
+func ParseLiteral(s string) (string, error) {
+	invalid := func(reason string) (string, error) {
+		return "", fmt.Errorf("%w: literal %q %s", ErrInvalid, s, reason)
+	}
+	i := 0
+	sign := ""
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		if s[i] == '-' {
+			sign = "-"
+		}
+		i++
+	}
+
+	// the mantissa
+	var intPart, fracPart []byte
+	buf := &intPart
+	hasDigit, hasPoint := false, false
+	for ; i < len(s) && s[i] != 'e' && s[i] != 'E'; i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			*buf = append(*buf, c)
+			hasDigit = true
+		case c == '_':
+			if !isDigitAt(s, i-1) || !isDigitAt(s, i+1) {
+				return invalid("has an underscore not between digits")
+			}
+		case c == '.' && !hasPoint:
+			hasPoint = true
+			buf = &fracPart
+		default:
+			return invalid(fmt.Sprintf("has an invalid character %q", c))
+		}
+	}
+	if !hasDigit {
+		return invalid("has no digits")
+	}
+
+	// the exponent
+	exp := 0
+	if i < len(s) {
+		i++ // skip 'e' or 'E'
+		expSign := 1
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+			if s[i] == '-' {
+				expSign = -1
+			}
+			i++
+		}
+		var digits []byte
+		for ; i < len(s); i++ {
+			switch {
+			case isDigitAt(s, i):
+				digits = append(digits, s[i])
+			case s[i] == '_' && isDigitAt(s, i-1) && isDigitAt(s, i+1):
+			default:
+				return invalid("has an invalid exponent")
+			}
+		}
+		var err error
+		exp, err = strconv.Atoi(string(digits))
+		if err != nil || exp > maxLiteralExponent {
+			return invalid("has an invalid exponent")
+		}
+		exp *= expSign
+	}
+
+	normalized := string(compose(intPart, fracPart))
+	if normalized == "0" {
+		return normalized, nil
+	}
+	return shiftPoint(sign+normalized, exp), nil
+}
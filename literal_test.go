@@ -0,0 +1,75 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseLiteral(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		err  error
+	}{
+		{"0", "0", nil},
+		{"-0.0e5", "0", nil},
+		{"1_000", "1000", nil},
+		{"1_000.000_1", "1000.0001", nil},
+		{"+007.50", "7.5", nil},
+		{".5", "0.5", nil},
+		{"1.", "1", nil},
+		{"-1.25e2", "-125", nil},
+		{"1.5E-3", "0.0015", nil},
+		{"1e1_0", "10000000000", nil},
+		{"12e+0", "12", nil},
+		{"", "", ErrInvalid},
+		{".", "", ErrInvalid},
+		{"e5", "", ErrInvalid},
+		{"_1", "", ErrInvalid},
+		{"1_", "", ErrInvalid},
+		{"1__0", "", ErrInvalid},
+		{"1_.5", "", ErrInvalid},
+		{"1._5", "", ErrInvalid},
+		{"1.2.3", "", ErrInvalid},
+		{"1,5", "", ErrInvalid},
+		{"1e", "", ErrInvalid},
+		{"1e-", "", ErrInvalid},
+		{"1e5x", "", ErrInvalid},
+		{"1e1001", "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := ParseLiteral(test.s)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ParseLiteral(%q) = (%q, %v), want (%q, %v)", test.s, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	if err := GoLiteral.Validate(); err != nil {
+		t.Errorf("GoLiteral.Validate() = %v", err)
+	}
+	for _, decimal := range []string{"0", "-12", "1234567.891", "1 234,5"} {
+		literal, ok := GoLiteral.Convert(decimal)
+		if !ok {
+			t.Errorf("GoLiteral.Convert(%q) failed", decimal)
+			continue
+		}
+		back, err := ParseLiteral(literal)
+		if want, _ := toNormalized(decimal); err != nil || back != want {
+			t.Errorf("ParseLiteral(GoLiteral.Convert(%q)) = (%q, %v), want %q", decimal, back, err, want)
+		}
+	}
+}
+
+func ExampleParseLiteral() {
+	normalized, _ := ParseLiteral("1_234.5e3")
+	fmt.Println(normalized)
+	literal, _ := GoLiteral.Convert("1234567.89")
+	fmt.Println(literal)
+	// Output:
+	// 1234500
+	// 1_234_567.89
+}
@@ -0,0 +1,70 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// localeFormats are the decimal formats of the locales (as BCP 47 tags) and of their languages.
+// The no-break spaces used by some locales are replaced by the space.
+var localeFormats = map[string]DecimalFormat{
+	"cs": {',', ' ', true}, "cs-CZ": {',', ' ', true},
+	"da": {',', '.', true}, "da-DK": {',', '.', true},
+	"de": {',', '.', true}, "de-DE": {',', '.', true}, "de-AT": {',', ' ', true}, "de-CH": {'.', '\'', true},
+	"en": {'.', ',', true}, "en-US": {'.', ',', true}, "en-GB": {'.', ',', true}, "en-CA": {'.', ',', true},
+	"en-AU": {'.', ',', true}, "en-IN": {'.', ',', false}, "en-ZA": {',', ' ', true},
+	"es": {',', '.', true}, "es-ES": {',', '.', true}, "es-MX": {'.', ',', true},
+	"fi": {',', ' ', true}, "fi-FI": {',', ' ', true},
+	"fr": {',', ' ', true}, "fr-FR": {',', ' ', true}, "fr-BE": {',', ' ', true}, "fr-CA": {',', ' ', true}, "fr-CH": {',', ' ', true},
+	"hi": {'.', ',', false}, "hi-IN": {'.', ',', false},
+	"it": {',', '.', true}, "it-IT": {',', '.', true}, "it-CH": {'.', '\'', true},
+	"ja": {'.', ',', true}, "ja-JP": {'.', ',', true},
+	"ko": {'.', ',', true}, "ko-KR": {'.', ',', true},
+	"nb": {',', ' ', true}, "nb-NO": {',', ' ', true},
+	"nl": {',', '.', true}, "nl-NL": {',', '.', true}, "nl-BE": {',', '.', true},
+	"pl": {',', ' ', true}, "pl-PL": {',', ' ', true},
+	"pt": {',', '.', true}, "pt-BR": {',', '.', true}, "pt-PT": {',', ' ', true},
+	"ru": {',', ' ', true}, "ru-RU": {',', ' ', true},
+	"sv": {',', ' ', true}, "sv-SE": {',', ' ', true},
+	"tr": {',', '.', true}, "tr-TR": {',', '.', true},
+	"zh": {'.', ',', true}, "zh-CN": {'.', ',', true},
+}
+
+// regionLocales are the main locales of the regions (as ISO 3166 country codes).
+var regionLocales = map[string]string{
+	"AT": "de-AT", "AU": "en-AU", "BE": "nl-BE", "BR": "pt-BR", "CA": "en-CA",
+	"CH": "de-CH", "CN": "zh-CN", "CZ": "cs-CZ", "DE": "de-DE", "DK": "da-DK",
+	"ES": "es-ES", "FI": "fi-FI", "FR": "fr-FR", "GB": "en-GB", "IN": "en-IN",
+	"IT": "it-IT", "JP": "ja-JP", "KR": "ko-KR", "MX": "es-MX", "NL": "nl-NL",
+	"NO": "nb-NO", "PL": "pl-PL", "PT": "pt-PT", "RU": "ru-RU", "SE": "sv-SE",
+	"TR": "tr-TR", "US": "en-US", "ZA": "en-ZA",
+}
+
+// FormatForLocale returns the usual DecimalFormat of a locale, given as a BCP 47 tag
+// like "de-CH" (or "de_CH", case insensitive). If the locale is unknown, the format of
+// its language is used, like "fr" for "fr-LU". The no-break spaces used by some locales,
+// like "fr-FR", are replaced by the space.
+// If the language is unknown, the error wraps ErrLanguage.
+func FormatForLocale(tag string) (DecimalFormat, error) {
+	lang, region, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	lang = strings.ToLower(lang)
+	if df, ok := localeFormats[lang+"-"+strings.ToUpper(region)]; ok {
+		return df, nil
+	}
+	if df, ok := localeFormats[lang]; ok {
+		return df, nil
+	}
+	return DecimalFormat{}, fmt.Errorf("%w: locale %q", ErrLanguage, tag)
+}
+
+// FormatForRegion returns the usual DecimalFormat of a region given as an ISO 3166
+// country code, like "DE" (case insensitive). It is the format of the main locale
+// of the region (e.g. "de-CH" for "CH"), see FormatForLocale.
+// If the region is unknown, the error wraps ErrLanguage.
+func FormatForRegion(cc string) (DecimalFormat, error) {
+	locale, ok := regionLocales[strings.ToUpper(cc)]
+	if !ok {
+		return DecimalFormat{}, fmt.Errorf("%w: region %q", ErrLanguage, cc)
+	}
+	return localeFormats[locale], nil
+}
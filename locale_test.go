@@ -0,0 +1,71 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFormatForLocale(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want DecimalFormat
+		err  error
+	}{
+		{"en-US", DecimalFormat{Point: '.', Group: ',', Standard: true}, nil},
+		{"de_ch", DecimalFormat{Point: '.', Group: '\'', Standard: true}, nil},
+		{"fr-LU", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"hi-IN", DecimalFormat{Point: '.', Group: ',', Standard: false}, nil},
+		{"DE", DecimalFormat{Point: ',', Group: '.', Standard: true}, nil},
+		{"xx-XX", DecimalFormat{}, ErrLanguage},
+		{"", DecimalFormat{}, ErrLanguage},
+	}
+
+	for _, test := range tests {
+		got, err := FormatForLocale(test.tag)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("FormatForLocale(%q) = (%v, %v), want (%v, %v)", test.tag, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestFormatForRegion(t *testing.T) {
+	tests := []struct {
+		cc   string
+		want DecimalFormat
+		err  error
+	}{
+		{"DE", DecimalFormat{Point: ',', Group: '.', Standard: true}, nil},
+		{"ch", DecimalFormat{Point: '.', Group: '\'', Standard: true}, nil},
+		{"IN", DecimalFormat{Point: '.', Group: ',', Standard: false}, nil},
+		{"US", DecimalFormat{Point: '.', Group: ',', Standard: true}, nil},
+		{"XX", DecimalFormat{}, ErrLanguage},
+	}
+
+	for _, test := range tests {
+		got, err := FormatForRegion(test.cc)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("FormatForRegion(%q) = (%v, %v), want (%v, %v)", test.cc, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestLocaleTables(t *testing.T) {
+	for tag, df := range localeFormats {
+		if err := df.Validate(); err != nil {
+			t.Errorf("the format of %q is not valid: %v", tag, err)
+		}
+	}
+	for cc, locale := range regionLocales {
+		if _, ok := localeFormats[locale]; !ok {
+			t.Errorf("the locale %q of the region %q is unknown", locale, cc)
+		}
+	}
+}
+
+func ExampleFormatForRegion() {
+	df, _ := FormatForRegion("CH")
+	s, _ := df.Convert("1234567.5")
+	fmt.Println(s)
+	// Output: 1'234'567.5
+}
@@ -0,0 +1,105 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Matches checks if the decimal string strictly conforms to the DecimalFormat.
+// See MatchesErr for the rules.
+func (df DecimalFormat) Matches(decimal string) bool {
+	return df.MatchesErr(decimal) == nil
+}
+
+// MatchesErr checks if the decimal string strictly conforms to the DecimalFormat.
+// It returns an error wrapping ErrInvalidFormat if the format is not valid,
+// and an error wrapping ErrMismatch if the string does not match the format.
+// A matching string:
+//   - may start with a '-' or a '+' sign, but contains no spaces other than grouping separators;
+//   - has at least one digit before the decimal separator (if any) and at least one digit after it;
+//   - has its integer part grouped exactly as Convert would do it: groups of 3 digits
+//     (or 3 then 2 digits if the format is non-standard), and no grouping for 3 digits or less.
+//
+// Example:
+//
+//	{`,`, `.`, standard}.MatchesErr("1.234,5")  => nil
+//	{`,`, `.`, standard}.MatchesErr("1234,5")   => ErrMismatch (missing grouping separator)
+//	{`,`, `.`, standard}.MatchesErr("12.34,5")  => ErrMismatch (wrong group size)
+func (df DecimalFormat) MatchesErr(decimal string) error {
+	if err := df.Validate(); err != nil {
+		return err
+	}
+	// mismatch returns the ErrMismatch error with the reason.
+	mismatch := func(reason string) error {
+		return fmt.Errorf("%w %v: %q %s", ErrMismatch, df, decimal, reason)
+	}
+
+	abs := decimal
+	if len(abs) > 0 && (abs[0] == '-' || abs[0] == '+') {
+		abs = abs[1:]
+	}
+
+	// split the integer and the fractional parts
+	intPart, fracPart, hasPoint := abs, "", false
+	if df.Point != NoSeparator {
+		intPart, fracPart, hasPoint = strings.Cut(abs, string(df.Point))
+	}
+	if hasPoint && len(fracPart) == 0 {
+		return mismatch("has no digits after the decimal separator")
+	}
+	if !isDigits(fracPart) {
+		return mismatch("has an invalid fractional part")
+	}
+
+	// check the integer part group by group, starting from the right
+	groups := []string{intPart}
+	if df.Group != NoSeparator {
+		groups = strings.Split(intPart, string(df.Group))
+	}
+	size := 3
+	if !df.Standard {
+		size = 2
+	}
+	last := len(groups) - 1
+	for i := last; i >= 0; i-- {
+		g := groups[i]
+		if len(g) == 0 {
+			return mismatch("has a missing digit")
+		}
+		if !isDigits(g) {
+			return mismatch("has an invalid integer part")
+		}
+		switch {
+		case df.Group == NoSeparator:
+			// no grouping rules
+		case last == 0:
+			if len(g) > 3 {
+				return mismatch("has a missing grouping separator")
+			}
+		case i == last:
+			if len(g) != 3 {
+				return mismatch("has a wrong last group size")
+			}
+		case i == 0:
+			if len(g) > size {
+				return mismatch("has a missing grouping separator")
+			}
+		default:
+			if len(g) != size {
+				return mismatch("has a wrong group size")
+			}
+		}
+	}
+	return nil
+}
+
+// isDigits checks if the string contains only ASCII digits.
+// It returns true for the empty string.
+func isDigits[T bytestr](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
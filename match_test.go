@@ -0,0 +1,71 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	var (
+		fr    = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+		de    = DecimalFormat{Point: ',', Group: '.', Standard: true}
+		in    = DecimalFormat{Point: '.', Group: ',', Standard: false}
+		plain = DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}
+		ints  = DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}
+	)
+	tests := []struct {
+		df      DecimalFormat
+		decimal string
+		err     error
+	}{
+		{fr, "0", nil},
+		{fr, "123", nil},
+		{fr, "1 234", nil},
+		{fr, "-1 234 567,89", nil},
+		{fr, "+12 345,6", nil},
+		{fr, "0,5", nil},
+		{fr, "1234", ErrMismatch},
+		{fr, "1 23", ErrMismatch},
+		{fr, "12 34 567", ErrMismatch},
+		{fr, "1 234.5", ErrMismatch},
+		{fr, ",5", ErrMismatch},
+		{fr, "1,", ErrMismatch},
+		{fr, "1,2,3", ErrMismatch},
+		{fr, " 1", ErrMismatch},
+		{fr, "- 1", ErrMismatch},
+		{fr, "", ErrMismatch},
+		{fr, "1  234", ErrMismatch},
+		{de, "1.234.567,891", nil},
+		{de, "1.234.567.891", nil},
+		{de, "1.234 567", ErrMismatch},
+		{in, "12,34,567.89", nil},
+		{in, "1,234", nil},
+		{in, "1,234,567", ErrMismatch},
+		{in, "123,45,678", ErrMismatch},
+		{plain, "1234567.125", nil},
+		{plain, "1,234", ErrMismatch},
+		{ints, "1'234", nil},
+		{ints, "1'234.5", ErrMismatch},
+		{DecimalFormat{Point: '.', Group: '.', Standard: true}, "1", ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		err := test.df.MatchesErr(test.decimal)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("(%v).MatchesErr(%q) = %v, want %v", test.df, test.decimal, err, test.err)
+		}
+		if got := test.df.Matches(test.decimal); got != (test.err == nil) {
+			t.Errorf("(%v).Matches(%q) = %v, want %v", test.df, test.decimal, got, test.err == nil)
+		}
+	}
+}
+
+func ExampleDecimalFormat_Matches() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	fmt.Println(df.Matches("1.234,5"))
+	fmt.Println(df.Matches("1234,5"))
+	// Output:
+	// true
+	// false
+}
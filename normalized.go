@@ -0,0 +1,147 @@
+package decstr
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// Normalized is a decimal string that is normalized (see IsNormalized).
+// It can only be created by NewNormalized, the unmarshalers and its methods, that guarantee it,
+// so that the raw and the normalized values are distinguished at compile time,
+// and the normalized values do not need to be checked again.
+// The zero value is the zero "0".
+type Normalized struct {
+	value string // the normalized decimal, or "" for the zero "0"
+}
+
+// NewNormalized returns the normalized version of the decimal string,
+// or the error returned by Parse with the same options.
+// It fails with ErrInvalid if the options produce a string that is not normalized
+// (like WithLeadingZeros), or a special value (see WithSpecialValues).
+func NewNormalized(decimal string, opts ...Option) (Normalized, error) {
+	normalized, _, err := Parse(decimal, opts...)
+	if err != nil {
+		return Normalized{}, err
+	}
+	if !IsNormalized(normalized) {
+		return Normalized{}, fmt.Errorf("%w: %q is not normalized to a decimal (%q)", ErrInvalid, decimal, normalized)
+	}
+	return normalizedOf(normalized), nil
+}
+
+// normalizedOf returns the Normalized of the normalized decimal string,
+// using the zero value for "0", so that the equal decimals are equal with ==.
+func normalizedOf(normalized string) Normalized {
+	if normalized == "0" {
+		return Normalized{}
+	}
+	return Normalized{normalized}
+}
+
+// String returns the normalized decimal string.
+func (n Normalized) String() string {
+	if n.value == "" {
+		return "0"
+	}
+	return n.value
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n Normalized) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts any decimal string that NewNormalized accepts, like "1 234,5".
+func (n *Normalized) UnmarshalText(text []byte) error {
+	normalized, err := NewNormalized(string(text))
+	if err != nil {
+		return err
+	}
+	*n = normalized
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, writing the normalized decimal as the element text.
+func (n Normalized) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(n.String(), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+// It accepts the element text that UnmarshalText accepts, surrounded by any white space.
+// If the element has an xml:lang attribute, like <sum xml:lang="de">1.234</sum>,
+// the text must be written in the format of this locale (see FormatForLocale),
+// that also resolves the ambiguous values.
+func (n *Normalized) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	text = strings.TrimSpace(text)
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "lang" && (attr.Name.Space == "xml" || attr.Name.Space == xmlNamespace) {
+			return n.unmarshalIn(attr.Value, text)
+		}
+	}
+	if err := n.UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("decstr: element <%s>: %w", start.Name.Local, err)
+	}
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (n Normalized) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: n.String()}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr, like UnmarshalXML for the attribute value.
+func (n *Normalized) UnmarshalXMLAttr(attr xml.Attr) error {
+	if err := n.UnmarshalText([]byte(strings.TrimSpace(attr.Value))); err != nil {
+		return fmt.Errorf("decstr: attribute %s: %w", attr.Name.Local, err)
+	}
+	return nil
+}
+
+// xmlNamespace is the namespace of the xml prefix, used by xml.Decoder for xml:lang.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// unmarshalIn sets n to the decimal written in the format of the locale.
+func (n *Normalized) unmarshalIn(locale, decimal string) error {
+	df, err := FormatForLocale(locale)
+	if err == nil {
+		decimal, err = parseIn(df, decimal, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("decstr: xml:lang %q: %w", locale, err)
+	}
+	*n = normalizedOf(decimal)
+	return nil
+}
+
+// Convert converts the normalized decimal to the DecimalFormat, like DecimalFormat.Convert,
+// but without checking the decimal again.
+func (n Normalized) Convert(df DecimalFormat, opts ...Option) (string, bool) {
+	if len(opts) > 0 {
+		return df.Convert(n.String(), opts...)
+	}
+	if df.Validate() != nil {
+		return "0", false
+	}
+	buf, ok := appendNormalized(make([]byte, 0, convertedLen(df, n.String())), df, n.String())
+	if !ok {
+		return "0", false
+	}
+	return string(buf), true
+}
+
+// Round rounds the decimal to scale fraction digits (0 if negative) with the rounding mode,
+// like RoundSig does for the significant figures.
+func (n Normalized) Round(scale int, mode RoundingMode) Normalized {
+	return normalizedOf(roundMode(n.String(), scale, mode))
+}
+
+// Cmp compares the decimals by value, and returns -1 if n < m, 0 if n == m and +1 if n > m.
+func (n Normalized) Cmp(m Normalized) int {
+	return compareNormalized(n.String(), m.String())
+}
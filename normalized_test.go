@@ -0,0 +1,170 @@
+package decstr
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNewNormalized(t *testing.T) {
+	tests := []struct {
+		decimal string
+		opts    []Option
+		want    Normalized
+		err     error
+	}{
+		{"1 234,50", nil, Normalized{"1234.5"}, nil},
+		{"-0,0", nil, Normalized{}, nil},
+		{"1,234", nil, Normalized{}, ErrAmbiguous},
+		{"007", []Option{WithLeadingZeros()}, Normalized{}, ErrInvalid},
+		{"-0", []Option{WithNegativeZero(NegativeZeroKeep)}, Normalized{}, ErrInvalid},
+		{"Inf", []Option{WithSpecialValues()}, Normalized{}, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := NewNormalized(test.decimal, test.opts...)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("NewNormalized(%q) = (%v, %v), want (%v, %v)", test.decimal, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestNormalizedText(t *testing.T) {
+	var v struct {
+		Price Normalized `json:"price"`
+		Zero  Normalized `json:"zero"`
+	}
+	if err := json.Unmarshal([]byte(`{"price": "1 234,5"}`), &v); err != nil {
+		t.Fatalf("json.Unmarshal error = %v", err)
+	}
+	if v.Price.String() != "1234.5" {
+		t.Errorf("json.Unmarshal price = %v, want %q", v.Price, "1234.5")
+	}
+	b, err := json.Marshal(v)
+	if err != nil || string(b) != `{"price":"1234.5","zero":"0"}` {
+		t.Errorf("json.Marshal = (%s, %v), want %s", b, err, `{"price":"1234.5","zero":"0"}`)
+	}
+	if err := json.Unmarshal([]byte(`{"price": "1,234"}`), &v); !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("json.Unmarshal of an ambiguous price error = %v, want ErrAmbiguous", err)
+	}
+}
+
+func TestNormalizedXML(t *testing.T) {
+	type claim struct {
+		ID     Normalized   `xml:"id,attr"`
+		Amount Normalized   `xml:"amount"`
+		Fees   []Normalized `xml:"fee"`
+	}
+	data := `<claim id=" 42 ">
+	<amount xml:lang="de-DE">
+		1.234
+	</amount>
+	<fee>1 234,5</fee>
+	<fee xml:lang="en">1,234</fee>
+</claim>`
+	var c claim
+	if err := xml.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("xml.Unmarshal error = %v", err)
+	}
+	if got := fmt.Sprint(c); got != "{42 1234 [1234.5 1234]}" {
+		t.Errorf("xml.Unmarshal = %s, want {42 1234 [1234.5 1234]}", got)
+	}
+	b, err := xml.Marshal(c)
+	want := `<claim id="42"><amount>1234</amount><fee>1234.5</fee><fee>1234</fee></claim>`
+	if err != nil || string(b) != want {
+		t.Errorf("xml.Marshal = (%s, %v), want %s", b, err, want)
+	}
+
+	errs := []struct {
+		data string
+		err  error
+	}{
+		{`<claim><amount>1,234</amount></claim>`, ErrAmbiguous},
+		{`<claim><amount xml:lang="de">1,234.5</amount></claim>`, ErrMismatch},
+		{`<claim><amount xml:lang="xx">1,5</amount></claim>`, ErrLanguage},
+		{`<claim id="4 2"></claim>`, ErrInvalid},
+	}
+	for _, test := range errs {
+		if err := xml.Unmarshal([]byte(test.data), &c); !errors.Is(err, test.err) {
+			t.Errorf("xml.Unmarshal(%s) error = %v, want %v", test.data, err, test.err)
+		}
+	}
+}
+
+func TestNormalizedConvert(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	tests := []struct {
+		n    Normalized
+		df   DecimalFormat
+		opts []Option
+		want string
+		ok   bool
+	}{
+		{Normalized{"-1234567.5"}, df, nil, "-1 234 567,5", true},
+		{Normalized{}, df, nil, "0", true},
+		{Normalized{"12.5"}, df, []Option{WithPlusSign()}, "+12,5", true},
+		{Normalized{"12.5"}, DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil, "0", false},
+		{Normalized{"12"}, DecimalFormat{Point: ',', Group: ',', Standard: true}, nil, "0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.n.Convert(test.df, test.opts...)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Normalized(%v).Convert(%v) = (%q, %v), want (%q, %v)", test.n, test.df, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestNormalizedRound(t *testing.T) {
+	tests := []struct {
+		n     Normalized
+		scale int
+		mode  RoundingMode
+		want  Normalized
+	}{
+		{Normalized{"1234.565"}, 2, HalfEven, Normalized{"1234.56"}},
+		{Normalized{"1234.565"}, 2, HalfAwayFromZero, Normalized{"1234.57"}},
+		{Normalized{"-9.96"}, 1, HalfAwayFromZero, Normalized{"-10"}},
+		{Normalized{"-0.04"}, 1, HalfAwayFromZero, Normalized{}},
+		{Normalized{"0.04"}, -1, Ceiling, Normalized{"1"}},
+		{Normalized{"12.5"}, 3, Floor, Normalized{"12.5"}},
+		{Normalized{}, 2, Floor, Normalized{}},
+	}
+
+	for _, test := range tests {
+		if got := test.n.Round(test.scale, test.mode); got != test.want {
+			t.Errorf("Normalized(%v).Round(%d, %d) = %v, want %v", test.n, test.scale, test.mode, got, test.want)
+		}
+	}
+}
+
+func TestNormalizedCmp(t *testing.T) {
+	tests := []struct {
+		a, b Normalized
+		want int
+	}{
+		{Normalized{"1.5"}, Normalized{"1.25"}, 1},
+		{Normalized{"-1.5"}, Normalized{"-1.25"}, -1},
+		{Normalized{"-0.5"}, Normalized{}, -1},
+		{Normalized{}, Normalized{}, 0},
+		{Normalized{"100"}, Normalized{"99.99"}, 1},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Cmp(test.b); got != test.want {
+			t.Errorf("Normalized(%v).Cmp(%v) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func ExampleNormalized() {
+	n, err := NewNormalized("1 234,50")
+	if err != nil {
+		panic(err)
+	}
+	s, _ := n.Convert(DecimalFormat{Point: '.', Group: ',', Standard: true})
+	fmt.Println(n, s)
+	// Output: 1234.5 1,234.5
+}
@@ -0,0 +1,365 @@
+package decstr
+
+import "math/rand/v2"
+
+// Option configures the functions that accept options.
+type Option func(*options)
+
+// options holds the configuration set by the Option functions.
+type options struct {
+	bufferSize    int          // the size of the chunks read by the streaming functions
+	scalePercent  bool         // if percent values are scaled (12.5% <-> 0.125)
+	permille      bool         // if the permille sign is used instead of the percent one
+	percentSpace  string       // the space between the number and the percent sign
+	specialValues bool         // if NaN and infinities are accepted
+	nanName       string       // the name of NaN written by Convert
+	infName       string       // the name of the infinity written by Convert
+	maxLength     int          // the maximal length in bytes of the parsed strings, 0 for no limit
+	maxGroups     int          // the maximal number of grouping separators, 0 for no limit
+	noOuterSpaces bool         // if leading and trailing spaces are rejected
+	noSignSpaces  bool         // if spaces between the sign and the digits are rejected
+	standardOnly  bool         // if the non-standard grouping (like "1 23 456") is rejected
+	noPlusSign    bool         // if the '+' sign is rejected
+	unicodeMinus  bool         // if the minus sign '−' (U+2212) is accepted
+	mixedSpaces   bool         // if the different Unicode spaces are the same grouping separator
+	trimSpace     bool         // if the leading and trailing Unicode white space is ignored
+	si            bool         // if the SI rules are enforced by Parse and Convert
+	resolve       bool         // if the ambiguous strings are resolved with their most likely format
+	trailingText  bool         // if the text after the number is ignored
+	leadingZeros  bool         // if the leading zeros of the integer part are kept
+	negativeZero  NegativeZero // how the negative zero is handled
+	positiveSign  byte         // the sign written by Convert before the positive numbers, 0 for none
+	width         int          // the minimal width in runes of the output of Convert
+	leftAlign     bool         // if the output of Convert is padded on the right
+	zeroPadding   bool         // if the output of Convert is padded with zeros after the sign
+	minScale      int          // the minimal number of fraction digits written by Convert
+	sigFigs       int          // the number of significant figures written by Convert, 0 for all
+	sigMode       RoundingMode // the rounding mode to the significant figures
+	accounting    bool         // if Convert writes the accounting style, like "(1 234,50)"
+	currency      string       // the currency symbol written by Convert before the number
+	workers       int          // the number of goroutines used by the batch functions
+	rand          *rand.Rand   // the source of the random decimals of Generate, nil for the global one
+	nearMiss      bool         // if Generate produces invalid strings
+}
+
+// defaultBufferSize is the default size of the chunks read by the streaming functions.
+const defaultBufferSize = 4096
+
+// newOptions returns the default options modified by opts.
+func newOptions(opts []Option) *options {
+	o := &options{
+		bufferSize: defaultBufferSize,
+		nanName:    "NaN",
+		infName:    "Inf",
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithBufferSize sets the size of the chunks read by the streaming functions.
+// Values smaller than 1 are ignored.
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.bufferSize = size
+		}
+	}
+}
+
+// WithWorkers sets the number of goroutines used by the batch functions
+// NormalizeAll and ConvertAll. By default the values are processed sequentially.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// WithRand sets the source of randomness of Generate, so that a seeded source,
+// like rand.New(rand.NewPCG(1, 2)), produces a reproducible sequence of decimals.
+// By default the global source of math/rand/v2 is used.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) {
+		o.rand = r
+	}
+}
+
+// WithNearMiss makes Generate produce near-miss invalid strings: valid decimals in the format
+// with one edit (like an inserted separator or a removed digit) that makes them invalid in it.
+func WithNearMiss() Option {
+	return func(o *options) {
+		o.nearMiss = true
+	}
+}
+
+// WithScaledPercent scales the percent (and permille) values:
+// ParsePercent("12,5 %") returns "0.125" instead of "12.5",
+// and ConvertPercent("0.125") returns "12.5%" instead of "0.125%".
+func WithScaledPercent() Option {
+	return func(o *options) {
+		o.scalePercent = true
+	}
+}
+
+// WithPermille makes ConvertPercent use the permille sign '‰' instead of '%'.
+func WithPermille() Option {
+	return func(o *options) {
+		o.permille = true
+	}
+}
+
+// WithPercentSpace sets the space written by ConvertPercent between the number and
+// the percent sign, e.g. " " or the narrow no-break space "\u202F" used in French.
+// By default there is no space.
+func WithPercentSpace(space string) Option {
+	return func(o *options) {
+		o.percentSpace = space
+	}
+}
+
+// WithSpecialValues enables NaN and infinities, like "NaN", "-Inf" or "+∞" (see Parse).
+// They are returned in their canonical form "NaN", "Inf" or "-Inf" by Parse, and by Convert
+// unless other names are set with WithSpecialNames.
+func WithSpecialValues() Option {
+	return func(o *options) {
+		o.specialValues = true
+	}
+}
+
+// WithSpecialNames enables NaN and infinities (see WithSpecialValues) and sets
+// the names used by Convert to write them, like "NaN" and "∞".
+// The name of the negative infinity is the name of the infinity preceded by '-'.
+func WithSpecialNames(nan, inf string) Option {
+	return func(o *options) {
+		o.specialValues = true
+		o.nanName, o.infName = nan, inf
+	}
+}
+
+// WithMaxLength makes Parse reject with ErrTooLong the strings longer than n bytes,
+// before any processing. It is a guard against untrusted inputs. Values smaller than 1 are ignored.
+func WithMaxLength(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxLength = n
+		}
+	}
+}
+
+// WithMaxGroups makes Parse reject with ErrTooLong the decimals with more than n grouping separators,
+// like "1 234 567" for n = 1. Values smaller than 1 are ignored.
+func WithMaxGroups(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxGroups = n
+		}
+	}
+}
+
+// WithoutOuterSpaces makes Parse reject the strings with leading or trailing spaces,
+// like " 123" or "123 ", which are accepted by default.
+func WithoutOuterSpaces() Option {
+	return func(o *options) {
+		o.noOuterSpaces = true
+	}
+}
+
+// WithoutSignSpaces makes Parse reject the strings with spaces between the sign and the digits,
+// like "- 123", which are accepted by default.
+func WithoutSignSpaces() Option {
+	return func(o *options) {
+		o.noSignSpaces = true
+	}
+}
+
+// WithStandardGroupingOnly makes Parse reject the non-standard grouping,
+// like "1 34 567", so that only groups of 3 digits are accepted.
+func WithStandardGroupingOnly() Option {
+	return func(o *options) {
+		o.standardOnly = true
+	}
+}
+
+// WithTrimSpace makes Parse ignore all the leading and trailing Unicode white space
+// (see unicode.IsSpace), like the tabs, the line feeds and the no-break spaces
+// of "\u00A01 234,56\t", and not only the spaces. It has no effect with WithoutOuterSpaces.
+func WithTrimSpace() Option {
+	return func(o *options) {
+		o.trimSpace = true
+	}
+}
+
+// WithMixedSpaces makes Parse treat all the Unicode spaces, like the no-break space U+00A0,
+// the narrow no-break space U+202F or the thin space U+2009, as the same grouping space,
+// so that "1 234\u00A0567" is 1234567. By default, a number must use the same space in all its groups.
+func WithMixedSpaces() Option {
+	return func(o *options) {
+		o.mixedSpaces = true
+	}
+}
+
+// WithLeadingZeros makes Parse keep the leading zeros of the integer part,
+// so that "007,50" is "007.5" instead of "7.5" (the result is then not normalized, see IsNormalized).
+// It is useful for fixed-width codes that must round-trip.
+func WithLeadingZeros() Option {
+	return func(o *options) {
+		o.leadingZeros = true
+	}
+}
+
+// WithPlusSign makes Convert write a '+' before the positive numbers and the zero,
+// like "+1 234,56", for example to display differences.
+func WithPlusSign() Option {
+	return func(o *options) {
+		o.positiveSign = '+'
+	}
+}
+
+// WithSpaceSign makes Convert write a space before the positive numbers and the zero,
+// so that they are aligned with the negative numbers.
+func WithSpaceSign() Option {
+	return func(o *options) {
+		o.positiveSign = ' '
+	}
+}
+
+// WithWidth makes Convert pad its output with spaces to at least width runes,
+// so that the numbers line up in the columns of monospaced reports.
+// The output is right-aligned, unless WithLeftAlign is used.
+func WithWidth(width int) Option {
+	return func(o *options) {
+		o.width = width
+	}
+}
+
+// WithLeftAlign makes Convert pad its output on the right (see WithWidth).
+func WithLeftAlign() Option {
+	return func(o *options) {
+		o.leftAlign = true
+	}
+}
+
+// WithZeroPadding makes Convert pad its output with zeros after the sign
+// instead of spaces, like "-0001234,5" (see WithWidth).
+// The padding zeros are not grouped. It is ignored with WithLeftAlign.
+func WithZeroPadding() Option {
+	return func(o *options) {
+		o.zeroPadding = true
+	}
+}
+
+// WithMinScale makes Convert write at least n fraction digits, padding with zeros:
+// "1.5" is written "1,50" with n = 2. It is ignored if the format has no decimal separator.
+func WithMinScale(n int) Option {
+	return func(o *options) {
+		o.minScale = n
+	}
+}
+
+// WithSignificantFigures makes Convert round the decimal to sig significant figures
+// with the rounding mode (see RoundSig), and write the significant trailing zeros:
+// "1.5" is written "1,50" and "1234.5" is written "1 200" with sig = 3 and 2.
+// The zero is written with sig-1 fraction digits, like "0,00" with sig = 3.
+func WithSignificantFigures(sig int, mode RoundingMode) Option {
+	return func(o *options) {
+		o.sigFigs, o.sigMode = sig, mode
+	}
+}
+
+// WithAccounting makes Convert write the accounting style: the number is rounded
+// half away from zero to two fraction digits, and the negative numbers are written
+// in parentheses, like "(1 234,50)". The other numbers are followed by a space,
+// so that their digits line up with the negative ones. The sign options are ignored.
+// Without decimal separator in the format the number is rounded to an integer.
+func WithAccounting() Option {
+	return func(o *options) {
+		o.accounting = true
+	}
+}
+
+// WithCurrencySymbol makes Convert write symbol and a space before the number, like "€ 1 234,50".
+// With WithWidth the padding goes between the symbol and the number,
+// so that the symbols form their own column.
+func WithCurrencySymbol(symbol string) Option {
+	return func(o *options) {
+		o.currency = symbol
+	}
+}
+
+// NegativeZero is the policy for the negative zeros like "-0" or "-0,00", see WithNegativeZero.
+type NegativeZero int
+
+const (
+	// NegativeZeroToZero normalizes the negative zero to "0" (the default).
+	NegativeZeroToZero NegativeZero = iota
+	// NegativeZeroKeep normalizes the negative zero to "-0" (which is not normalized, see IsNormalized).
+	NegativeZeroKeep
+	// NegativeZeroReject makes Parse fail with ErrInvalid on the negative zero.
+	NegativeZeroReject
+)
+
+// WithNegativeZero sets the policy of Parse for the negative zeros like "-0" or "-0,00".
+// By default they are normalized to "0", like Normalize does.
+func WithNegativeZero(policy NegativeZero) Option {
+	return func(o *options) {
+		o.negativeZero = policy
+	}
+}
+
+// Strictness is a preset of the parsing options, see WithStrictness.
+type Strictness int
+
+const (
+	// Default is the default behavior of Parse.
+	Default Strictness = iota
+	// Strict rejects the sloppy inputs.
+	Strict
+	// Lenient accepts as many inputs as possible.
+	Lenient
+	// SIStrict accepts only the numbers following the SI (and ISO 80000) rules.
+	SIStrict
+)
+
+// WithStrictness sets all the parsing toggles of Parse to the given preset:
+//   - Default accepts the spaces around the string and after the sign ("  - 1 234 "),
+//     the '-' and '+' signs, and the non-standard grouping ("1 34 567"),
+//     but rejects the ambiguous strings ("1,234") and any text after the number ("12 kg").
+//   - Strict is like WithoutOuterSpaces, WithoutSignSpaces and WithStandardGroupingOnly together,
+//     and also rejects the '+' sign: only "-1 234,5" and "1 234,5" like strings are accepted.
+//   - Lenient is like Default, but also accepts the minus sign '−' (U+2212), mixes the spaces
+//     in a number (see WithMixedSpaces), ignores any white space around it (see WithTrimSpace),
+//     resolves the ambiguous strings with their most likely format (see DetectCandidates),
+//     so that "1,234" is 1234, and ignores the text after the number, so that "12,5 kg" is 12.5.
+//   - SIStrict is like Strict, but only accepts the SI (and ISO 80000) writing of the numbers:
+//     a point or a comma as decimal separator, preceded and followed by digits, a space,
+//     a thin space (U+2009) or a narrow no-break space (U+202F) as grouping separator,
+//     in groups of 3 digits on both sides of the decimal separator, starting from it,
+//     and no grouping of 4 digits: "12 345,678 91" and "1234.5678" are accepted, but not "1 234".
+//     The ambiguous strings are resolved, as the comma and the point cannot group digits: "1,234" is 1.234.
+//     Convert also writes the numbers following these rules with this preset,
+//     and fails if the format is not an SI one.
+//
+// The options following WithStrictness can change the individual toggles of the preset.
+func WithStrictness(level Strictness) Option {
+	return func(o *options) {
+		si := level == SIStrict
+		strict, lenient := level == Strict || si, level == Lenient
+		o.si = si
+		o.noOuterSpaces, o.noSignSpaces, o.standardOnly, o.noPlusSign = strict, strict, strict, strict
+		o.unicodeMinus, o.mixedSpaces, o.trimSpace = lenient, lenient, lenient
+		o.resolve, o.trailingText = lenient, lenient
+	}
+}
+
+// specialName returns the name of the canonical special value written by Convert.
+func (o *options) specialName(special string) string {
+	switch special {
+	case "NaN":
+		return o.nanName
+	case "-Inf":
+		return "-" + o.infName
+	default:
+		return o.infName
+	}
+}
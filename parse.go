@@ -0,0 +1,227 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parse detects the format of a decimal string and returns its normalized version,
+// like NormalizeCheck and DetectFormat, but reports failures with an error wrapping:
+//   - ErrAmbiguous if the string is ambiguous, like "1,234";
+//   - ErrSpecialValue if the string is a NaN or an infinity and WithSpecialValues is not used;
+//   - ErrTooLong if the string exceeds the limits set by WithMaxLength or WithMaxGroups;
+//   - ErrInvalid otherwise, through a *SyntaxError giving the position of the failure.
+//
+// By default the spaces around the string and after the sign are ignored,
+// use WithoutOuterSpaces and WithoutSignSpaces to reject them.
+// The non-standard grouping, like "1 34 567", is rejected with WithStandardGroupingOnly.
+// WithStrictness sets all these toggles at once with a preset.
+//
+// With WithSpecialValues, "NaN", "Inf", "Infinity" and "∞" (case insensitive, with an optional sign)
+// are accepted and returned in their canonical form "NaN", "Inf" or "-Inf", with a zero DecimalFormat.
+func Parse[T bytestr](decimal T, opts ...Option) (normalized T, df DecimalFormat, err error) {
+	o := newOptions(opts)
+	if o.maxLength > 0 && len(decimal) > o.maxLength {
+		return normalized, df, fmt.Errorf("%w: %d bytes, the limit is %d", ErrTooLong, len(decimal), o.maxLength)
+	}
+	if o.trimSpace && !o.noOuterSpaces {
+		decimal = T(strings.TrimSpace(string(decimal)))
+	}
+	if o.unicodeMinus {
+		decimal = replaceUnicodeMinus(decimal)
+	}
+	if o.mixedSpaces {
+		decimal = replaceSpaces(decimal)
+	}
+	if at := forbiddenSpace(decimal, o); at >= 0 {
+		return normalized, df, newSyntaxError(decimal, at)
+	}
+	if o.noPlusSign {
+		if s := trimLeft(decimal, ' '); len(s) > 0 && s[0] == '+' {
+			return normalized, df, newSyntaxError(decimal, len(decimal)-len(s))
+		}
+	}
+	if o.trailingText {
+		decimal = cutTrailingText(decimal)
+	}
+	if special, ok := parseSpecial(decimal); ok {
+		if !o.specialValues {
+			return normalized, df, fmt.Errorf("%w: %q", ErrSpecialValue, decimal)
+		}
+		return T(special), df, nil
+	}
+	var ok bool
+	if o.si {
+		if normalized, df, err = parseSI(decimal); err != nil {
+			return normalized, df, err
+		}
+		ok = true
+	} else {
+		normalized, df, ok = detectAndNormalize(decimal)
+	}
+	if ok {
+		if o.maxGroups > 0 && df.Group != NoSeparator {
+			if n := countGroup(decimal, df.Group); n > o.maxGroups {
+				return normalized[:0], DecimalFormat{}, fmt.Errorf("%w: %q has %d groups, the limit is %d", ErrTooLong, decimal, n, o.maxGroups)
+			}
+		}
+		if o.standardOnly && !df.Standard {
+			// the first group after the first separator has only 2 digits
+			return normalized[:0], DecimalFormat{}, newSyntaxError(decimal, indexGroup(decimal, df.Group))
+		}
+		if o.negativeZero != NegativeZeroToZero && string(normalized) == "0" {
+			if sign, _ := getSign(decimal); len(sign) > 0 {
+				if o.negativeZero == NegativeZeroReject {
+					return normalized[:0], DecimalFormat{}, fmt.Errorf("%w: %q is a negative zero", ErrInvalid, decimal)
+				}
+				normalized = T("-0")
+			}
+		}
+		if o.leadingZeros {
+			normalized = keepLeadingZeros(decimal, normalized, df)
+		}
+		return normalized, df, nil
+	}
+	if sep, ok := ambiguousSeparator(decimal); ok {
+		if o.resolve {
+			normalized, df = resolveAmbiguous(decimal, sep)
+			return normalized, df, nil
+		}
+		return normalized[:0], df, fmt.Errorf("%w: %q", ErrAmbiguous, decimal)
+	}
+	_, _, _, at := scanDecimal(decimal, nil, nil, nil)
+	return normalized[:0], df, newSyntaxError(decimal, at)
+}
+
+// forbiddenSpace returns the index of the first space rejected by
+// the WithoutOuterSpaces and WithoutSignSpaces options, or -1 if there is none.
+func forbiddenSpace[T bytestr](decimal T, o *options) int {
+	if o.noOuterSpaces && len(decimal) > 0 {
+		if decimal[0] == ' ' {
+			return 0
+		}
+		if decimal[len(decimal)-1] == ' ' {
+			return len(decimal) - 1
+		}
+	}
+	if o.noSignSpaces {
+		s := trimLeft(decimal, ' ')
+		if len(s) > 1 && (s[0] == '-' || s[0] == '+') && s[1] == ' ' {
+			return len(decimal) - len(s) + 1
+		}
+	}
+	return -1
+}
+
+// keepLeadingZeros puts back in the normalized decimal the leading zeros of
+// the integer part of the decimal string (with the format df).
+func keepLeadingZeros[T bytestr](decimal, normalized T, df DecimalFormat) T {
+	_, abs := getSign(decimal)
+	zeros := 0
+	for _, c := range string(abs) {
+		if c != '0' && spaceGroup(c) != df.Group {
+			break
+		}
+		if c == '0' {
+			zeros++
+		}
+	}
+	if zeros == 0 {
+		return normalized
+	}
+	sign, digits := "", string(normalized)
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if digits == "0" || strings.HasPrefix(digits, "0.") {
+		// the normalized zero integer part is replaced by the zeros
+		digits = digits[1:]
+	}
+	return T(sign + strings.Repeat("0", zeros) + digits)
+}
+
+// unicodeMinus is the minus sign '−' (U+2212) accepted by the Lenient preset.
+const unicodeMinus = "−"
+
+// replaceUnicodeMinus replaces the leading minus sign '−' of the decimal string by '-'.
+func replaceUnicodeMinus[T bytestr](decimal T) T {
+	s := trimLeft(decimal, ' ')
+	if len(s) < len(unicodeMinus) || string(s[:len(unicodeMinus)]) != unicodeMinus {
+		return decimal
+	}
+	return T(string(decimal[:len(decimal)-len(s)]) + "-" + string(s[len(unicodeMinus):]))
+}
+
+// cutTrailingText removes the text following the number at the start of the decimal string,
+// like " kg" in "12,5 kg".
+func cutTrailingText[T bytestr](decimal T) T {
+	s := trimLeft(decimal, ' ')
+	k := 0 // the index of the first digit in s
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		k = len(s) - len(trimLeft(s[1:], ' '))
+	}
+	if !isDigitAt(s, k) {
+		return decimal
+	}
+	return decimal[:len(decimal)-len(s)+runEnd(s, k)]
+}
+
+// resolveAmbiguous normalizes an ambiguous decimal string, like "1,234" with the ambiguous
+// separator sep, using its most likely format (see DetectCandidates).
+func resolveAmbiguous[T bytestr](decimal T, sep rune) (normalized T, df DecimalFormat) {
+	df = DetectCandidates(decimal)[0].Format
+	sign, abs := getSign(decimal)
+	intPart, fracPart := string(abs[:len(abs)-4]), string(abs[len(abs)-3:])
+	if df.Point != sep {
+		intPart, fracPart = intPart+fracPart, ""
+	}
+	intPart, fracPart = strings.TrimLeft(intPart, "0"), strings.TrimRight(fracPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	s := string(sign) + intPart
+	if fracPart != "" {
+		s += "." + fracPart
+	}
+	if s == "-0" {
+		s = "0"
+	}
+	return T(s), df
+}
+
+// parseSpecial checks if the decimal string is a NaN or an infinity, and returns
+// its canonical form: "NaN", "Inf" or "-Inf".
+func parseSpecial[T bytestr](decimal T) (special string, ok bool) {
+	sign, abs := getSign(decimal)
+	if len(abs) == 0 || len(abs) > len("infinity") {
+		return "", false
+	}
+	switch s := string(abs); {
+	case strings.EqualFold(s, "nan"):
+		return "NaN", true
+	case strings.EqualFold(s, "inf"), strings.EqualFold(s, "infinity"), s == "∞":
+		return string(sign) + "Inf", true
+	}
+	return "", false
+}
+
+// replaceSpaces replaces the Unicode spaces of the decimal string, like the no-break space, by ' '.
+func replaceSpaces[T bytestr](decimal T) T {
+	s := string(decimal)
+	if !strings.ContainsFunc(s, isUnicodeSpace) {
+		return decimal
+	}
+	return T(strings.Map(func(r rune) rune {
+		if isUnicodeSpace(r) {
+			return ' '
+		}
+		return r
+	}, s))
+}
+
+// isUnicodeSpace checks if r is a non ASCII Unicode space separator.
+func isUnicodeSpace(r rune) bool {
+	return r >= utf8.RuneSelf && unicode.Is(unicode.Zs, r)
+}
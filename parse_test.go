@@ -0,0 +1,229 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		decimal    string
+		opts       []Option
+		normalized string
+		df         DecimalFormat
+		err        error
+	}{
+		{"1 234,5", nil, "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"-12", nil, "-12", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"1,234", nil, "", DecimalFormat{}, ErrAmbiguous},
+		{"-1.234", nil, "", DecimalFormat{}, ErrAmbiguous},
+		{"1,23,4", nil, "", DecimalFormat{}, ErrInvalid},
+		{"", nil, "", DecimalFormat{}, ErrInvalid},
+		{"NaN", nil, "", DecimalFormat{}, ErrSpecialValue},
+		{"-inf", nil, "", DecimalFormat{}, ErrSpecialValue},
+		{"NaN", []Option{WithSpecialValues()}, "NaN", DecimalFormat{}, nil},
+		{" nan ", []Option{WithSpecialValues()}, "NaN", DecimalFormat{}, nil},
+		{"-NaN", []Option{WithSpecialValues()}, "NaN", DecimalFormat{}, nil},
+		{"Inf", []Option{WithSpecialValues()}, "Inf", DecimalFormat{}, nil},
+		{"+Infinity", []Option{WithSpecialValues()}, "Inf", DecimalFormat{}, nil},
+		{"- INF", []Option{WithSpecialValues()}, "-Inf", DecimalFormat{}, nil},
+		{"-∞", []Option{WithSpecialValues()}, "-Inf", DecimalFormat{}, nil},
+		{"infinit", []Option{WithSpecialValues()}, "", DecimalFormat{}, ErrInvalid},
+		{"1 234,5", []Option{WithSpecialValues()}, "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"1 234,5", []Option{WithMaxLength(7)}, "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"1 234,56", []Option{WithMaxLength(7)}, "", DecimalFormat{}, ErrTooLong},
+		{"1 234 567", []Option{WithMaxGroups(2)}, "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"1 234 567", []Option{WithMaxGroups(1)}, "", DecimalFormat{}, ErrTooLong},
+		{"1\u00A0234\u00A0567\u00A0890", []Option{WithMaxGroups(1)}, "", DecimalFormat{}, ErrTooLong},
+		{"1234567", []Option{WithMaxGroups(1)}, "1234567", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"  -   123  ", nil, "-123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"-123", []Option{WithoutOuterSpaces(), WithoutSignSpaces()}, "-123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{" -123", []Option{WithoutOuterSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{"-123 ", []Option{WithoutOuterSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{"- 123", []Option{WithoutOuterSpaces()}, "-123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"- 123", []Option{WithoutSignSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{" + 123 ", []Option{WithoutSignSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{" +123 ", []Option{WithoutSignSpaces()}, "123", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"- inf", []Option{WithSpecialValues(), WithoutSignSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{"_199", nil, "", DecimalFormat{}, ErrInvalid},
+		{"_002", nil, "", DecimalFormat{}, ErrInvalid},
+		{"-_199", nil, "", DecimalFormat{}, ErrInvalid},
+		{"1 34 567", nil, "134567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false}, nil},
+		{"1 34 567", []Option{WithStandardGroupingOnly()}, "", DecimalFormat{}, ErrInvalid},
+		{"12,34,567.5", []Option{WithStandardGroupingOnly()}, "", DecimalFormat{}, ErrInvalid},
+		{"1\u00A023\u00A0456", []Option{WithStandardGroupingOnly()}, "", DecimalFormat{}, ErrInvalid},
+		{"1\u202F23\u202F456", []Option{WithStrictness(Strict)}, "", DecimalFormat{}, ErrInvalid},
+		{"134 567,5", []Option{WithStandardGroupingOnly()}, "134567.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"1 234,5", []Option{WithStrictness(Strict)}, "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"+1 234,5", []Option{WithStrictness(Strict)}, "", DecimalFormat{}, ErrInvalid},
+		{" 1 234,5", []Option{WithStrictness(Strict)}, "", DecimalFormat{}, ErrInvalid},
+		{"- 1 234,5", []Option{WithStrictness(Strict)}, "", DecimalFormat{}, ErrInvalid},
+		{"1 34 567", []Option{WithStrictness(Strict)}, "", DecimalFormat{}, ErrInvalid},
+		{" 1 234,5", []Option{WithStrictness(Strict), WithStrictness(Default)}, "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"1,234", []Option{WithStrictness(Lenient)}, "1234", DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, nil},
+		{"-0,120", []Option{WithStrictness(Lenient)}, "-0.12", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"1'000", []Option{WithStrictness(Lenient)}, "1000", DecimalFormat{Point: NoSeparator, Group: '\'', Standard: true}, nil},
+		{"−1 234,5", []Option{WithStrictness(Lenient)}, "-1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"−1 234,5", nil, "", DecimalFormat{}, ErrInvalid},
+		{" 12,5 kg", []Option{WithStrictness(Lenient)}, "12.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"- 1,234 units", []Option{WithStrictness(Lenient)}, "-1234", DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, nil},
+		{"12,5 kg", nil, "", DecimalFormat{}, ErrInvalid},
+		{"1 234\u00A0567", nil, "", DecimalFormat{}, ErrInvalid},
+		{"1 234\u00A0567", []Option{WithMixedSpaces()}, "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"-1\u202F234\u2009567,5", []Option{WithMixedSpaces()}, "-1234567.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"\u00A012\u00A0345 ", []Option{WithMixedSpaces()}, "12345", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"1\u00A0234 567", []Option{WithStrictness(Lenient)}, "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"1 234\u00A0567", []Option{WithStrictness(Lenient), WithoutOuterSpaces()}, "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"12\u00A0", []Option{WithMixedSpaces(), WithoutOuterSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{"\u00A01 234,56 ", nil, "", DecimalFormat{}, ErrInvalid},
+		{"\u00A01 234,56 ", []Option{WithTrimSpace()}, "1234.56", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"\t-12.5\r\n", []Option{WithTrimSpace()}, "-12.5", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"\u2003\u00A01 234,56\u202F", []Option{WithStrictness(Lenient)}, "1234.56", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"\t12", []Option{WithTrimSpace(), WithoutOuterSpaces()}, "", DecimalFormat{}, ErrInvalid},
+		{"1\t234", []Option{WithTrimSpace()}, "", DecimalFormat{}, ErrInvalid},
+		{"kg", []Option{WithStrictness(Lenient)}, "", DecimalFormat{}, ErrInvalid},
+		{"007,50", []Option{WithLeadingZeros()}, "007.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"-012.30", []Option{WithLeadingZeros()}, "-012.3", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"00,5", []Option{WithLeadingZeros()}, "00.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"0,5", []Option{WithLeadingZeros()}, "0.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"0000", []Option{WithLeadingZeros()}, "0000", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"0 012 345", []Option{WithLeadingZeros()}, "0012345", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, nil},
+		{"12.5", []Option{WithLeadingZeros()}, "12.5", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"007,50", nil, "7.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"-0,00", nil, "0", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"-0,00", []Option{WithNegativeZero(NegativeZeroToZero)}, "0", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"-0,00", []Option{WithNegativeZero(NegativeZeroKeep)}, "-0", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"- 0", []Option{WithNegativeZero(NegativeZeroKeep)}, "-0", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"+0", []Option{WithNegativeZero(NegativeZeroKeep)}, "0", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+		{"-0.0", []Option{WithNegativeZero(NegativeZeroReject)}, "", DecimalFormat{}, ErrInvalid},
+		{"0.0", []Option{WithNegativeZero(NegativeZeroReject)}, "0", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"-000", []Option{WithNegativeZero(NegativeZeroKeep), WithLeadingZeros()}, "-000", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, nil},
+	}
+
+	for _, test := range tests {
+		normalized, df, err := Parse(test.decimal, test.opts...)
+		if normalized != test.normalized || df != test.df || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Parse(%q) = (%q, %v, %v), want (%q, %v, %v)", test.decimal, normalized, df, err, test.normalized, test.df, test.err)
+		}
+		bnormalized, bdf, berr := Parse([]byte(test.decimal), test.opts...)
+		if string(bnormalized) != normalized || bdf != df || (berr == nil) != (err == nil) {
+			t.Errorf("Parse([]byte(%q)) = (%q, %v, %v), want (%q, %v, %v)", test.decimal, bnormalized, bdf, berr, normalized, df, err)
+		}
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		decimal string
+		offset  int
+		r       rune
+	}{
+		{"1¸5", 1, '¸'},
+		{"12a", 2, 'a'},
+		{" -1 234 56", 7, ' '},
+		{"1 23 45", 4, ' '},
+		{"1234 567", 4, ' '},
+		{"1,234.5.6", 7, '.'},
+		{"1,234;5", 5, ';'},
+		{"1\xC2x", 1, utf8.RuneError},
+		{"-", 1, utf8.RuneError},
+		{"", 0, utf8.RuneError},
+	}
+
+	for _, test := range tests {
+		_, _, err := Parse(test.decimal)
+		var se *SyntaxError
+		if !errors.As(err, &se) || !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) error = %v, want a *SyntaxError", test.decimal, err)
+			continue
+		}
+		if se.Offset != test.offset || se.Rune != test.r {
+			t.Errorf("Parse(%q) error at (%d, %q), want (%d, %q)", test.decimal, se.Offset, se.Rune, test.offset, test.r)
+		}
+	}
+}
+
+func ExampleSyntaxError() {
+	_, _, err := Parse("1 234¸5")
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		fmt.Println(se.Offset, string(se.Rune))
+		fmt.Println(err)
+	}
+	// Output:
+	// 5 ¸
+	// decstr: invalid decimal string: unexpected '¸' at index 5 in "1 234¸5"
+}
+
+func TestParseSyntaxErrorUnicodeGroup(t *testing.T) {
+	// the detected group is ' ', but the error points at the actual space
+	for _, opt := range []Option{WithStandardGroupingOnly(), WithStrictness(Strict)} {
+		_, _, err := Parse("1\u00A023\u00A0456", opt)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Offset != 1 || se.Rune != '\u00A0' {
+			t.Errorf("Parse(%q) error = %v, want a *SyntaxError at (1, '\\u00A0')", "1\u00A023\u00A0456", err)
+		}
+	}
+	// the rejects at the end point at the start of the last (multi-byte) separator
+	tests := []struct {
+		decimal string
+		offset  int
+		r       rune
+	}{
+		{"1\u00A023", 1, '\u00A0'},
+		{"12\u202F345\u202F67", 8, '\u202F'},
+		{"-1\u2009234\u20095", 8, '\u2009'},
+	}
+	for _, test := range tests {
+		_, _, err := Parse(test.decimal)
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Offset != test.offset || se.Rune != test.r {
+			t.Errorf("Parse(%q) error = %v, want a *SyntaxError at (%d, %q)", test.decimal, err, test.offset, test.r)
+		}
+	}
+	if se := newSyntaxError("12", -1); se.Offset != 0 || se.Rune != '1' {
+		t.Errorf("newSyntaxError(%q, -1) = %+v, want the offset 0", "12", se)
+	}
+}
+
+func TestConvertSpecialValues(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	tests := []struct {
+		decimal string
+		opts    []Option
+		want    string
+		ok      bool
+	}{
+		{"NaN", nil, "0", false},
+		{"NaN", []Option{WithSpecialValues()}, "NaN", true},
+		{"-inf", []Option{WithSpecialValues()}, "-Inf", true},
+		{"∞", []Option{WithSpecialValues()}, "Inf", true},
+		{"-Inf", []Option{WithSpecialNames("n/a", "∞")}, "-∞", true},
+		{"nan", []Option{WithSpecialNames("n/a", "∞")}, "n/a", true},
+		{"1234.5", []Option{WithSpecialValues()}, "1 234,5", true},
+	}
+
+	for _, test := range tests {
+		got, ok := df.Convert(test.decimal, test.opts...)
+		if got != test.want || ok != test.ok {
+			t.Errorf("(%v).Convert(%q) = (%q, %v), want (%q, %v)", df, test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleParse() {
+	for _, s := range []string{"1 234,5", "1,234", "-Inf"} {
+		normalized, df, err := Parse(s, WithSpecialValues())
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(normalized, df)
+	}
+	// Output:
+	// 1234.5 {`,`, ` `, standard}
+	// decstr: ambiguous decimal format: "1,234"
+	// -Inf {`<none>`, `<none>`, non-standard}
+}
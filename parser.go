@@ -0,0 +1,55 @@
+package decstr
+
+// Parser detects and normalizes decimal strings like NormalizeCheck and DetectFormat,
+// but reuses its internal scratch space from one call to the next.
+// Once its buffers have grown to the size of the inputs, it does not allocate anymore,
+// which suits high-throughput callers.
+// The zero value is ready to use. A Parser is not safe for concurrent use.
+type Parser struct {
+	a, b []byte // scratch space for the integer and the decimal parts
+}
+
+// Normalize returns the normalized decimal and its detected format.
+// If the input is already normalized it is returned as is; otherwise the result
+// is stored in the internal buffer of the Parser and is only valid until the next call.
+// If ok is false, the input is returned unchanged.
+func (p *Parser) Normalize(decimal []byte) (normalized []byte, df DecimalFormat, ok bool) {
+	if IsNormalized(decimal) {
+		df, ok = normalizedFormat(decimal)
+		return decimal, df, ok
+	}
+	p.grow(len(decimal))
+	normalized, df, ok, _ = scanDecimal(decimal, p.a[:0], p.b[:0], nil)
+	if !ok {
+		if normalized, df, ok = scanCustom(decimal); !ok {
+			return decimal, df, false
+		}
+	}
+	return normalized, df, true
+}
+
+// NormalizeString is the string version of Normalize.
+// The result is a new string, so it is the only allocation of the call
+// (none if the input is already normalized or is not a decimal).
+func (p *Parser) NormalizeString(decimal string) (normalized string, df DecimalFormat, ok bool) {
+	if IsNormalized(decimal) {
+		df, ok = normalizedFormat(decimal)
+		return decimal, df, ok
+	}
+	p.grow(len(decimal))
+	buf, df, ok, _ := scanDecimal(decimal, p.a[:0], p.b[:0], nil)
+	if !ok {
+		if buf, df, ok = scanCustom(decimal); !ok {
+			return decimal, df, false
+		}
+	}
+	return string(buf), df, true
+}
+
+// grow ensures that the scratch buffers can hold n bytes.
+func (p *Parser) grow(n int) {
+	if cap(p.a) < n {
+		p.a = make([]byte, 0, n)
+		p.b = make([]byte, 0, n)
+	}
+}
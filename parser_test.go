@@ -0,0 +1,65 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	data := []string{
+		"123",
+		"-1 234,50",
+		"1.234",
+		"1,234.56",
+		"12 34 567.8",
+		" - 0012.30 ",
+		"not a decimal",
+		"",
+		"1234567890.123456789",
+		"1·5",
+	}
+	var p Parser
+	for _, decimal := range data {
+		want, wantDF, wantOK := detectAndNormalize(decimal)
+		got, df, ok := p.NormalizeString(decimal)
+		if got != want || df != wantDF || ok != wantOK {
+			t.Errorf("NormalizeString(%q) = (%q, %v, %v), want (%q, %v, %v)", decimal, got, df, ok, want, wantDF, wantOK)
+		}
+		gotBytes, df, ok := p.Normalize([]byte(decimal))
+		if string(gotBytes) != want || df != wantDF || ok != wantOK {
+			t.Errorf("Normalize(%q) = (%q, %v, %v), want (%q, %v, %v)", decimal, gotBytes, df, ok, want, wantDF, wantOK)
+		}
+	}
+}
+
+func TestParserAllocs(t *testing.T) {
+	var p Parser
+	decimal := []byte("-1 234 567,891")
+	p.Normalize(decimal) // grow the buffers
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Normalize(decimal)
+	})
+	if allocs != 0 {
+		t.Errorf("Parser.Normalize allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkParserNormalize(b *testing.B) {
+	var p Parser
+	decimal := []byte("1 234,50")
+	for i := 0; i < b.N; i++ {
+		p.Normalize(decimal)
+	}
+}
+
+func ExampleParser() {
+	var p Parser
+	for _, decimal := range []string{"1 234,5", "1,234.5", "12.5"} {
+		normalized, df, _ := p.NormalizeString(decimal)
+		fmt.Println(normalized, df)
+	}
+	// Output:
+	// 1234.5 {`,`, ` `, standard}
+	// 1234.5 {`.`, `,`, standard}
+	// 12.5 {`.`, `<none>`, standard}
+}
@@ -0,0 +1,68 @@
+package decstr
+
+import "strings"
+
+// isPercentSpace checks if the rune is a space that may separate a number from
+// the percent sign: a space, a no-break space, a narrow no-break space or a thin space.
+func isPercentSpace(r rune) bool {
+	switch r {
+	case ' ', '\u00A0', '\u202F', '\u2009':
+		return true
+	}
+	return false
+}
+
+// ParsePercent parses a decimal string followed by a percent '%' or a permille '‰' sign,
+// like "12,5 %" or "3.5‰", and returns the normalized decimal.
+// The sign may be preceded by a space, a no-break space, a narrow no-break space or a thin space.
+// By default the face value is returned ("12,5 %" => "12.5"), but with WithScaledPercent
+// the value is divided by 100 or 1000 ("12,5 %" => "0.125").
+// The boolean `ok` is false if there is no percent or permille sign,
+// or if the decimal part is not a valid decimal string (see NormalizeCheck).
+func ParsePercent(s string, opts ...Option) (value string, ok bool) {
+	o := newOptions(opts)
+	s = strings.TrimRightFunc(s, isPercentSpace)
+	var k int // the power of 10 of the scale
+	switch {
+	case strings.HasSuffix(s, "%"):
+		s, k = strings.TrimSuffix(s, "%"), -2
+	case strings.HasSuffix(s, "‰"):
+		s, k = strings.TrimSuffix(s, "‰"), -3
+	default:
+		return "", false
+	}
+	value, ok = NormalizeCheck(strings.TrimRightFunc(s, isPercentSpace))
+	if !ok {
+		return "", false
+	}
+	if o.scalePercent {
+		value = shiftPoint(value, k)
+	}
+	return value, true
+}
+
+// ConvertPercent converts a decimal string to a percent string using the DecimalFormat (see Convert).
+// By default the face value is used ("12.5" => "12.5%"), but with WithScaledPercent
+// the value is multiplied by 100 ("0.125" => "12.5%"), or by 1000 with WithPermille.
+// The sign is '%', or '‰' with WithPermille, and is preceded by the space set with WithPercentSpace.
+// The other options are the ones of Convert, like WithMinScale.
+// If the input string is not a valid decimal string, it returns "0" and false.
+func (df DecimalFormat) ConvertPercent(decimal string, opts ...Option) (new string, ok bool) {
+	o := newOptions(opts)
+	sign, k := "%", 2
+	if o.permille {
+		sign, k = "‰", 3
+	}
+	if o.scalePercent {
+		normalized, ok := toNormalized(decimal)
+		if !ok {
+			return "0", false
+		}
+		decimal = shiftPoint(normalized, k)
+	}
+	new, ok = df.Convert(decimal, opts...)
+	if !ok {
+		return new, false
+	}
+	return new + o.percentSpace + sign, true
+}
@@ -0,0 +1,82 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		s      string
+		scaled bool
+		value  string
+		ok     bool
+	}{
+		{"", false, "", false},
+		{"%", false, "", false},
+		{"12", false, "", false},
+		{"12%", false, "12", true},
+		{"12,5 %", false, "12.5", true},
+		{"12,5\u202F%", false, "12.5", true},
+		{"-1 234,5\u00A0% ", false, "-1234.5", true},
+		{"12,5 %", true, "0.125", true},
+		{"3.5‰", false, "3.5", true},
+		{"3.5 ‰", true, "0.0035", true},
+		{"1,234 %", false, "", false}, // ambiguous
+		{"abc %", false, "", false},
+	}
+
+	for _, test := range tests {
+		var opts []Option
+		if test.scaled {
+			opts = append(opts, WithScaledPercent())
+		}
+		value, ok := ParsePercent(test.s, opts...)
+		if value != test.value || ok != test.ok {
+			t.Errorf("ParsePercent(%q, scaled: %v) = (%q, %v), want (%q, %v)", test.s, test.scaled, value, ok, test.value, test.ok)
+		}
+	}
+}
+
+func TestConvertPercent(t *testing.T) {
+	var (
+		en = DecimalFormat{Point: '.', Group: ',', Standard: true}
+		fr = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	)
+	tests := []struct {
+		df      DecimalFormat
+		decimal string
+		opts    []Option
+		want    string
+		ok      bool
+	}{
+		{en, "12.5", nil, "12.5%", true},
+		{en, "1234.5", nil, "1,234.5%", true},
+		{en, "0.125", []Option{WithScaledPercent()}, "12.5%", true},
+		{en, "0.0035", []Option{WithScaledPercent(), WithPermille()}, "3.5‰", true},
+		{fr, "-0.125", []Option{WithScaledPercent(), WithPercentSpace("\u202F")}, "-12,5\u202F%", true},
+		{fr, "12,5", []Option{WithPercentSpace(" ")}, "12,5 %", true},
+		{en, "0.125", []Option{WithScaledPercent(), WithMinScale(2)}, "12.50%", true},
+		{en, "12", []Option{WithPlusSign()}, "+12%", true},
+		{fr, "abc", nil, "0", false},
+		{fr, "abc", []Option{WithScaledPercent()}, "0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.df.ConvertPercent(test.decimal, test.opts...)
+		if got != test.want || ok != test.ok {
+			t.Errorf("(%v).ConvertPercent(%q) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleDecimalFormat_ConvertPercent() {
+	value, _ := ParsePercent("12.5%", WithScaledPercent())
+	fmt.Println(value)
+	de := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	percent, _ := de.ConvertPercent(value, WithScaledPercent(), WithPercentSpace(" "))
+	fmt.Println(percent)
+	// Output:
+	// 0.125
+	// 12,5 %
+}
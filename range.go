@@ -0,0 +1,58 @@
+package decstr
+
+import "strings"
+
+// rangeSeparators are the separators between the bounds of a range, tried in order.
+// The words are surrounded by spaces, the dashes may be.
+var rangeSeparators = []string{"–", "—", "-", "..", " to ", " à "}
+
+// ParseRange parses a range of two decimals, like "1,5–2,5", and returns its normalized bounds.
+// The bounds are separated by a hyphen, an en dash, an em dash, "..", " to " or " à ",
+// with optional spaces around the dashes, and must share a format (see DetectFormatAll),
+// so that "1,5-2,500" is [1.5, 2.5]. A negative bound is accepted ("-5 - -2"),
+// but the lower bound cannot be greater than the upper one.
+// It returns false if s is not such a range.
+// Example:
+//
+//	ParseRange("1,5–2,5")        => "1.5", "2.5", true
+//	ParseRange("1 000 à 2 500")  => "1000", "2500", true
+//	ParseRange("-0.5 to 0.5")    => "-0.5", "0.5", true
+//	ParseRange("2024-01")        => "", "", false
+func ParseRange(s string) (lo, hi string, ok bool) {
+	s = strings.TrimSpace(s)
+	for _, sep := range rangeSeparators {
+		for from := 0; from < len(s); {
+			k := strings.Index(s[from:], sep)
+			if k < 0 {
+				break
+			}
+			k += from
+			if lo, hi, ok := parseBounds(s[:k], s[k+len(sep):]); ok {
+				return lo, hi, true
+			}
+			from = k + 1
+		}
+	}
+	return "", "", false
+}
+
+// parseBounds normalizes the bounds of a range, that must share a format.
+func parseBounds(lo, hi string) (string, string, bool) {
+	lo, hi = strings.TrimSpace(lo), strings.TrimSpace(hi)
+	if lo == "" || hi == "" {
+		return "", "", false
+	}
+	df, err := DetectFormatAll([]string{lo, hi})
+	if err != nil {
+		return "", "", false
+	}
+	lo, err = parseIn(df, lo, nil)
+	if err != nil {
+		return "", "", false
+	}
+	hi, err = parseIn(df, hi, nil)
+	if err != nil || compareNormalized(lo, hi) > 0 {
+		return "", "", false
+	}
+	return lo, hi, true
+}
@@ -0,0 +1,53 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		s      string
+		lo, hi string
+		ok     bool
+	}{
+		{"1,5–2,5", "1.5", "2.5", true},
+		{"1,5 – 2,5", "1.5", "2.5", true},
+		{"1,5-2,500", "1.5", "2.5", true},
+		{"1.5—2.25", "1.5", "2.25", true},
+		{"1 000 à 2 500", "1000", "2500", true},
+		{"1,000.5 to 2,500", "1000.5", "2500", true},
+		{"1,000 to 2,500", "", "", false},
+		{"1,5 to 2,500", "1.5", "2.5", true},
+		{"-0.5 to 0.5", "-0.5", "0.5", true},
+		{"-5-10", "-5", "10", true},
+		{"-5 - -2", "-5", "-2", true},
+		{" 0..100 ", "0", "100", true},
+		{"7-7", "7", "7", true},
+		{"2024-01", "", "", false},
+		{"5--2", "", "", false},
+		{"1,5-2.5", "", "", false},
+		{"1,234-2,345", "", "", false},
+		{"1,5", "", "", false},
+		{"1,5to2,5", "", "", false},
+		{"a-b", "", "", false},
+		{"", "", "", false},
+	}
+
+	for _, test := range tests {
+		lo, hi, ok := ParseRange(test.s)
+		if lo != test.lo || hi != test.hi || ok != test.ok {
+			t.Errorf("ParseRange(%q) = (%q, %q, %v), want (%q, %q, %v)", test.s, lo, hi, ok, test.lo, test.hi, test.ok)
+		}
+	}
+}
+
+func ExampleParseRange() {
+	for _, tolerance := range []string{"1,5–2,5", "0.25 to 1.5", "1,5–2,5 mm"} {
+		fmt.Println(ParseRange(tolerance))
+	}
+	// Output:
+	// 1.5 2.5 true
+	// 0.25 1.5 true
+	//   false
+}
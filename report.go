@@ -0,0 +1,62 @@
+package decstr
+
+import "strings"
+
+// Report describes a decimal string, as returned by Detect.
+type Report struct {
+	Format            DecimalFormat // the detected format
+	Normalized        string        // the normalized decimal string
+	Sign              byte          // the sign of the input: '-', '+' or 0 if there is none
+	IntDigits         int           // the number of digits of the integer part, including the leading zeros
+	FracDigits        int           // the number of digits of the fractional part, including the trailing zeros
+	GroupSizes        []int         // the sizes of the groups of the integer part, from left to right
+	AlreadyNormalized bool          // if the input was already normalized
+}
+
+// Detect detects the format of a decimal string like Parse does, and reports
+// what it has observed in it. For example Detect("-1 234 567,50") returns
+//
+//	Report{
+//		Format:     DecimalFormat{Point: ',', Group: ' ', Standard: true},
+//		Normalized: "-1234567.5",
+//		Sign:       '-',
+//		IntDigits:  7,
+//		FracDigits: 2,
+//		GroupSizes: []int{1, 3, 3},
+//	}
+//
+// The integer part of a string without grouping separator is a single group.
+// The error is the one returned by Parse.
+func Detect(s string) (Report, error) {
+	normalized, df, err := Parse(s)
+	if err != nil {
+		return Report{}, err
+	}
+	r := Report{
+		Format:            df,
+		Normalized:        normalized,
+		AlreadyNormalized: normalized == s,
+	}
+	sign, abs := getSign(s)
+	if len(sign) > 0 {
+		r.Sign = '-'
+	} else if t := trimLeft(s, ' '); t[0] == '+' {
+		r.Sign = '+'
+	}
+	intPart, fracPart := abs, ""
+	if df.Point != NoSeparator {
+		intPart, fracPart, _ = strings.Cut(abs, string(df.Point))
+	}
+	r.FracDigits = len(fracPart)
+	groups := []string{intPart}
+	if df.Group != NoSeparator {
+		// the group ' ' stands for any Unicode space
+		groups = strings.FieldsFunc(intPart, func(r rune) bool { return spaceGroup(r) == df.Group })
+	}
+	r.GroupSizes = make([]int, len(groups))
+	for i, g := range groups {
+		r.GroupSizes[i] = len(g)
+		r.IntDigits += len(g)
+	}
+	return r, nil
+}
@@ -0,0 +1,52 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Report
+		err  error
+	}{
+		{"-1 234 567,50", Report{
+			Format:     DecimalFormat{Point: ',', Group: ' ', Standard: true},
+			Normalized: "-1234567.5", Sign: '-', IntDigits: 7, FracDigits: 2, GroupSizes: []int{1, 3, 3},
+		}, nil},
+		{"12.5", Report{
+			Format:     DecimalFormat{Point: '.', Group: NoSeparator, Standard: true},
+			Normalized: "12.5", IntDigits: 2, FracDigits: 1, GroupSizes: []int{2}, AlreadyNormalized: true,
+		}, nil},
+		{" + 12 34 567", Report{
+			Format:     DecimalFormat{Point: NoSeparator, Group: ' ', Standard: false},
+			Normalized: "1234567", Sign: '+', IntDigits: 7, GroupSizes: []int{2, 2, 3},
+		}, nil},
+		{"007·5", Report{
+			Format:     DecimalFormat{Point: '·', Group: NoSeparator, Standard: true},
+			Normalized: "7.5", IntDigits: 3, FracDigits: 1, GroupSizes: []int{3},
+		}, nil},
+		{"1\u00A0234\u00A0567", Report{
+			Format:     DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true},
+			Normalized: "1234567", IntDigits: 7, GroupSizes: []int{1, 3, 3},
+		}, nil},
+		{"1,234", Report{}, ErrAmbiguous},
+		{"abc", Report{}, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := Detect(test.s)
+		if !reflect.DeepEqual(got, test.want) || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Detect(%q) = (%+v, %v), want (%+v, %v)", test.s, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleDetect() {
+	r, _ := Detect("1.234.567,5")
+	fmt.Println(r.Format, r.Normalized, r.IntDigits, r.FracDigits, r.GroupSizes)
+	// Output: {`,`, `.`, standard} 1234567.5 7 1 [1 3 3]
+}
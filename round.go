@@ -0,0 +1,135 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoundingMode is the way a decimal is rounded.
+type RoundingMode int
+
+const (
+	// HalfAwayFromZero rounds to the nearest, and the ties away from zero: 2.5 => 3, -2.5 => -3.
+	HalfAwayFromZero RoundingMode = iota
+	// HalfEven rounds to the nearest, and the ties to the even digit (banker's rounding): 2.5 => 2, 3.5 => 4.
+	HalfEven
+	// AwayFromZero rounds away from zero: 2.1 => 3, -2.1 => -3.
+	AwayFromZero
+	// TowardZero rounds toward zero (truncation): 2.9 => 2, -2.9 => -2.
+	TowardZero
+	// Ceiling rounds toward the positive infinity: 2.1 => 3, -2.9 => -2.
+	Ceiling
+	// Floor rounds toward the negative infinity: 2.9 => 2, -2.1 => -3.
+	Floor
+)
+
+// roundFrac rounds the normalized decimal string to the given number of fraction digits
+// (0 if negative), rounding half away from zero, and returns a normalized decimal string.
+// Example:
+//
+//	roundFrac("1.25", 1)   => "1.3"
+//	roundFrac("-9.96", 1)  => "-10"
+//	roundFrac("0.04", 1)   => "0"
+func roundFrac(normalized string, digits int) string {
+	return roundMode(normalized, digits, HalfAwayFromZero)
+}
+
+// roundMode is roundFrac with the given rounding mode.
+func roundMode(normalized string, digits int, mode RoundingMode) string {
+	digits = max(digits, 0)
+	sign, abs := "", normalized
+	if abs[0] == '-' {
+		sign, abs = "-", abs[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(abs, ".")
+	if len(fracPart) <= digits {
+		return normalized
+	}
+	kept := []byte(intPart + fracPart[:digits])
+	// the dropped digits are not all zeros, as the decimal is normalized
+	dropped := fracPart[digits:]
+	var up bool
+	switch mode {
+	case HalfAwayFromZero:
+		up = dropped[0] >= '5'
+	case HalfEven:
+		tie := dropped == "5"
+		up = dropped[0] > '5' || (dropped[0] == '5' && !tie) || (tie && (kept[len(kept)-1]-'0')%2 == 1)
+	case AwayFromZero:
+		up = true
+	case Ceiling:
+		up = sign == ""
+	case Floor:
+		up = sign == "-"
+	}
+	if up {
+		kept = incrementDigits(kept)
+	}
+	n := len(kept) - digits // the length of the new integer part
+	rounded := string(compose(kept[:n:n], kept[n:]))
+	if rounded == "0" {
+		return rounded
+	}
+	return sign + rounded
+}
+
+// RoundSig rounds the decimal string to sig significant figures (at least 1) with the rounding mode,
+// and returns a normalized decimal string. The rounding is exact, on the digits of the string.
+// The input does not need to be normalized; if it is not a valid decimal string,
+// the error wraps ErrInvalid.
+// To write the trailing zeros that are significant, like in "1.50", see WithSignificantFigures.
+// Example:
+//
+//	RoundSig("1 234,5", 2, HalfAwayFromZero)   => "1200", nil
+//	RoundSig("0.0012345", 3, HalfEven)         => "0.00123", nil
+//	RoundSig("-9.96", 2, HalfAwayFromZero)     => "-10", nil
+func RoundSig(decimal string, sig int, mode RoundingMode) (string, error) {
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, decimal)
+	}
+	return roundSig(normalized, sig, mode), nil
+}
+
+// roundSig rounds the normalized decimal string to sig significant figures (at least 1).
+func roundSig(normalized string, sig int, mode RoundingMode) string {
+	digits := max(sig, 1) - magnitude(normalized)
+	if digits >= 0 {
+		return roundMode(normalized, digits, mode)
+	}
+	// round the integer part, shifted to drop the digits after the last significant one
+	return shiftPoint(roundMode(shiftPoint(normalized, digits), 0, mode), -digits)
+}
+
+// sigScale returns the number of fraction digits needed to write sig significant figures
+// of the normalized decimal string (at least 1), like 2 for "1.5" and 3 figures.
+// The zero is written with sig-1 fraction digits.
+func sigScale(normalized string, sig int) int {
+	if normalized == "0" {
+		return max(sig, 1) - 1
+	}
+	return max(max(sig, 1)-magnitude(normalized), 0)
+}
+
+// magnitude returns the position of the first significant digit of the normalized decimal string,
+// relative to the decimal point: 4 for "1234.5", 1 for "1.5", -2 for "0.0012" and 0 for "0".
+func magnitude(normalized string) int {
+	intPart, fracPart, _ := strings.Cut(strings.TrimPrefix(normalized, "-"), ".")
+	if intPart != "0" {
+		return len(intPart)
+	}
+	return -(len(fracPart) - len(strings.TrimLeft(fracPart, "0")))
+}
+
+// incrementDigits adds one to the number written with the ASCII digits
+// and returns it (it may be one digit longer).
+func incrementDigits(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
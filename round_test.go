@@ -0,0 +1,116 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRoundFrac(t *testing.T) {
+	tests := []struct {
+		normalized string
+		digits     int
+		want       string
+	}{
+		{"0", 2, "0"},
+		{"12", 0, "12"},
+		{"1.25", 2, "1.25"},
+		{"1.25", 1, "1.3"},
+		{"1.24", 1, "1.2"},
+		{"-1.25", 1, "-1.3"},
+		{"1.5", 0, "2"},
+		{"1.5", -1, "2"},
+		{"9.96", 1, "10"},
+		{"-99.95", 1, "-100"},
+		{"0.04", 1, "0"},
+		{"-0.04", 1, "0"},
+		{"0.05", 1, "0.1"},
+		{"1.2049", 2, "1.2"},
+	}
+
+	for _, test := range tests {
+		got := roundFrac(test.normalized, test.digits)
+		if got != test.want {
+			t.Errorf("roundFrac(%q, %d) = %q, want %q", test.normalized, test.digits, got, test.want)
+		}
+	}
+}
+
+func TestRoundMode(t *testing.T) {
+	tests := []struct {
+		normalized string
+		mode       RoundingMode
+		want       string
+	}{
+		{"2.5", HalfAwayFromZero, "3"},
+		{"-2.5", HalfAwayFromZero, "-3"},
+		{"2.5", HalfEven, "2"},
+		{"3.5", HalfEven, "4"},
+		{"-2.5", HalfEven, "-2"},
+		{"2.51", HalfEven, "3"},
+		{"2.49", HalfEven, "2"},
+		{"2.1", AwayFromZero, "3"},
+		{"-2.1", AwayFromZero, "-3"},
+		{"2.9", TowardZero, "2"},
+		{"-2.9", TowardZero, "-2"},
+		{"-0.9", TowardZero, "0"},
+		{"2.1", Ceiling, "3"},
+		{"-2.9", Ceiling, "-2"},
+		{"2.9", Floor, "2"},
+		{"-2.1", Floor, "-3"},
+		{"-0.1", Ceiling, "0"},
+		{"7", Floor, "7"},
+	}
+
+	for _, test := range tests {
+		got := roundMode(test.normalized, 0, test.mode)
+		if got != test.want {
+			t.Errorf("roundMode(%q, 0, %d) = %q, want %q", test.normalized, test.mode, got, test.want)
+		}
+	}
+}
+
+func TestRoundSig(t *testing.T) {
+	tests := []struct {
+		decimal string
+		sig     int
+		mode    RoundingMode
+		want    string
+		err     error
+	}{
+		{"1 234,5", 2, HalfAwayFromZero, "1200", nil},
+		{"1 250", 2, HalfEven, "1200", nil},
+		{"1 350", 2, HalfEven, "1400", nil},
+		{"1234.5", 4, HalfEven, "1234", nil},
+		{"1235.5", 4, HalfEven, "1236", nil},
+		{"1234.5", 6, HalfEven, "1234.5", nil},
+		{"0.0012345", 3, HalfEven, "0.00123", nil},
+		{"0.0012345", 3, AwayFromZero, "0.00124", nil},
+		{"-9.96", 2, HalfAwayFromZero, "-10", nil},
+		{"-9.96", 2, TowardZero, "-9.9", nil},
+		{"99 999", 3, HalfAwayFromZero, "100000", nil},
+		{"-0.00045", 1, Ceiling, "-0.0004", nil},
+		{"0.00041", 1, Ceiling, "0.0005", nil},
+		{"0", 3, HalfAwayFromZero, "0", nil},
+		{"15", 0, HalfAwayFromZero, "20", nil},
+		{"1,234", 2, HalfAwayFromZero, "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := RoundSig(test.decimal, test.sig, test.mode)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("RoundSig(%q, %d, %v) = (%q, %v), want (%q, %v)", test.decimal, test.sig, test.mode, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleRoundSig() {
+	for _, v := range []string{"6,02214076", "0,000123456", "299 792 458"} {
+		rounded, _ := RoundSig(v, 3, HalfEven)
+		fmt.Println(rounded)
+	}
+	// Output:
+	// 6.02
+	// 0.000123
+	// 300000000
+}
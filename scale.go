@@ -0,0 +1,54 @@
+package decstr
+
+import "strings"
+
+// scale returns the number of fraction digits of the normalized decimal string.
+func scale(normalized string) int {
+	if i := strings.IndexByte(normalized, '.'); i >= 0 {
+		return len(normalized) - i - 1
+	}
+	return 0
+}
+
+// padScale appends zeros to the normalized decimal string, so that it has
+// at least n fraction digits. The result is not normalized anymore.
+// Example:
+//
+//	padScale("1.5", 3) => "1.500"
+//	padScale("12", 2)  => "12.00"
+func padScale(normalized string, n int) string {
+	missing := n - scale(normalized)
+	if missing <= 0 {
+		return normalized
+	}
+	if missing == n {
+		normalized += "."
+	}
+	return normalized + strings.Repeat("0", missing)
+}
+
+// AlignScale normalizes the decimal strings and pads them with zeros,
+// so that they all have the same number of fraction digits, the largest one:
+// AlignScale([]string{"1,5", "12", "0.125"}) returns []string{"1.500", "12.000", "0.125"}.
+// The invalid values are returned unchanged.
+// To render a column with aligned decimal points in another format, convert the values
+// with the WithMinScale option and the common scale instead.
+func AlignScale(values []string) []string {
+	aligned := make([]string, len(values))
+	valid := make([]bool, len(values))
+	n := 0
+	for i, v := range values {
+		aligned[i], valid[i] = toNormalized(v)
+		if !valid[i] {
+			aligned[i] = v
+			continue
+		}
+		n = max(n, scale(aligned[i]))
+	}
+	for i := range aligned {
+		if valid[i] {
+			aligned[i] = padScale(aligned[i], n)
+		}
+	}
+	return aligned
+}
@@ -0,0 +1,54 @@
+package decstr
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPadScale(t *testing.T) {
+	tests := []struct {
+		normalized string
+		n          int
+		want       string
+	}{
+		{"1.5", 3, "1.500"},
+		{"12", 2, "12.00"},
+		{"-0.125", 2, "-0.125"},
+		{"7", 0, "7"},
+	}
+
+	for _, test := range tests {
+		if got := padScale(test.normalized, test.n); got != test.want {
+			t.Errorf("padScale(%q, %d) = %q, want %q", test.normalized, test.n, got, test.want)
+		}
+	}
+}
+
+func TestAlignScale(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   []string
+	}{
+		{[]string{"1,5", "12", "0.125"}, []string{"1.500", "12.000", "0.125"}},
+		{[]string{"1 234", "-5"}, []string{"1234", "-5"}},
+		{[]string{"1.5", "n/a", "2,25"}, []string{"1.50", "n/a", "2.25"}},
+		{nil, []string{}},
+	}
+
+	for _, test := range tests {
+		if got := AlignScale(test.values); !slices.Equal(got, test.want) {
+			t.Errorf("AlignScale(%q) = %q, want %q", test.values, got, test.want)
+		}
+	}
+}
+
+func ExampleAlignScale() {
+	for _, v := range AlignScale([]string{"1 234,5", "12", "-0,25"}) {
+		fmt.Printf("|%8s|\n", v)
+	}
+	// Output:
+	// | 1234.50|
+	// |   12.00|
+	// |   -0.25|
+}
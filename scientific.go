@@ -0,0 +1,84 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NormalizeScientific returns the canonical scientific notation of a decimal string,
+// a normal form suited to deduplication keys or keyword indexes. This form:
+//   - may start with a '-' (negative sign);
+//   - has a single non-zero digit before the '.' (the zero is "0e0");
+//   - has no '.' if there are no more digits, and no trailing zeros after it;
+//   - ends with 'e' and the exponent, always present, without '+' nor leading zeros.
+//
+// The input does not need to be normalized, but if it is not a valid decimal string,
+// it returns "0e0" and false.
+// Example:
+//
+//	NormalizeScientific("0.00012")  => "1.2e-4", true
+//	NormalizeScientific("-1 234,5") => "-1.2345e3", true
+//	NormalizeScientific("7")        => "7e0", true
+func NormalizeScientific(decimal string) (string, bool) {
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return "0e0", false
+	}
+	sign, abs := "", normalized
+	if abs[0] == '-' {
+		sign, abs = "-", abs[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(abs, ".")
+	digits := intPart + fracPart
+	exp := len(intPart) - 1
+	// the leading zeros of the fraction of "0.00012"
+	significant := strings.TrimLeft(digits, "0")
+	exp -= len(digits) - len(significant)
+	significant = strings.TrimRight(significant, "0")
+	if significant == "" {
+		return "0e0", true
+	}
+	mantissa := significant[:1]
+	if len(significant) > 1 {
+		mantissa += "." + significant[1:]
+	}
+	return sign + mantissa + "e" + strconv.Itoa(exp), true
+}
+
+// ParseScientific parses a number in scientific notation whose mantissa may use
+// any decimal format, like "1,23E4" or "1.234,5e-2" exported by spreadsheets in
+// european locales, and returns its exact normalized value and the format of the mantissa.
+// The exponent follows an 'e' or an 'E', and is an integer with an optional sign
+// whose absolute value cannot exceed 1000. A string without exponent is parsed like Parse.
+// A mantissa is not grouped, so its only separator is a decimal one, even if
+// it is followed by 3 digits like in "1.234E+05".
+// The errors are the ones of Parse for the mantissa, or wrap ErrInvalid for the exponent.
+// Example:
+//
+//	ParseScientific("1,23E4")     => "12300", {`,`, `<none>`, standard}, nil
+//	ParseScientific("1.234,5e-2") => "12.345", {`,`, `.`, standard}, nil
+//	ParseScientific("1.234E+05")  => "123400", {`.`, `<none>`, standard}, nil
+func ParseScientific(s string) (normalized string, df DecimalFormat, err error) {
+	i := strings.LastIndexAny(s, "eE")
+	if i < 0 {
+		return Parse(s)
+	}
+	normalized, df, err = Parse(s[:i])
+	if errors.Is(err, ErrAmbiguous) {
+		// the ambiguous separator of the mantissa is the decimal one
+		mantissa := strings.TrimSpace(s[:i])
+		sep, _ := ambiguousSeparator(mantissa)
+		normalized, df, err = Parse(strings.Replace(mantissa, string(sep), "·", 1))
+		df.Point = sep
+	}
+	if err != nil {
+		return "", DecimalFormat{}, err
+	}
+	exp, err := strconv.Atoi(trimRight(s[i+1:], ' '))
+	if err != nil || exp < -maxLiteralExponent || exp > maxLiteralExponent {
+		return "", DecimalFormat{}, fmt.Errorf("%w: %q has an invalid exponent", ErrInvalid, s)
+	}
+	return shiftPoint(normalized, exp), df, nil
+}
@@ -0,0 +1,86 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNormalizeScientific(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    string
+		ok      bool
+	}{
+		{"0.00012", "1.2e-4", true},
+		{"-1 234,5", "-1.2345e3", true},
+		{"7", "7e0", true},
+		{"0", "0e0", true},
+		{"-0,00", "0e0", true},
+		{"1200", "1.2e3", true},
+		{"12.5", "1.25e1", true},
+		{"0.5", "5e-1", true},
+		{"1000000", "1e6", true},
+		{"1,234", "0e0", false},
+		{"abc", "0e0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := NormalizeScientific(test.decimal)
+		if got != test.want || ok != test.ok {
+			t.Errorf("NormalizeScientific(%q) = (%q, %v), want (%q, %v)", test.decimal, got, ok, test.want, test.ok)
+		}
+		if !ok {
+			continue
+		}
+		// the scientific form is a valid Go literal with the same value
+		back, err := ParseLiteral(got)
+		if want, _ := toNormalized(test.decimal); err != nil || back != want {
+			t.Errorf("ParseLiteral(%q) = (%q, %v), want %q", got, back, err, want)
+		}
+	}
+}
+
+func TestParseScientific(t *testing.T) {
+	tests := []struct {
+		s          string
+		normalized string
+		df         DecimalFormat
+		err        error
+	}{
+		{"1,23E4", "12300", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"1.234,5e-2", "12.345", DecimalFormat{Point: ',', Group: '.', Standard: true}, nil},
+		{" -1 234,5E+3 ", "-1234500", DecimalFormat{Point: ',', Group: ' ', Standard: true}, nil},
+		{"1.5e0", "1.5", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"0,00e5", "0", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"12,5", "12.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"1,234e2", "123.4", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{"1.234E+05", "123400", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, nil},
+		{"1,234E3", "1234", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, nil},
+		{" -1'234e-1", "-0.1234", DecimalFormat{Point: '\'', Group: NoSeparator, Standard: true}, nil},
+		{"1,234", "", DecimalFormat{}, ErrAmbiguous},
+		{"1,5e", "", DecimalFormat{}, ErrInvalid},
+		{"1,5e1.5", "", DecimalFormat{}, ErrInvalid},
+		{"1,5e1001", "", DecimalFormat{}, ErrInvalid},
+		{"e5", "", DecimalFormat{}, ErrInvalid},
+	}
+
+	for _, test := range tests {
+		normalized, df, err := ParseScientific(test.s)
+		if normalized != test.normalized || df != test.df || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("ParseScientific(%q) = (%q, %v, %v), want (%q, %v, %v)", test.s, normalized, df, err, test.normalized, test.df, test.err)
+		}
+	}
+}
+
+func ExampleParseScientific() {
+	normalized, df, _ := ParseScientific("1.234,5e-2")
+	fmt.Println(normalized, df)
+	// Output: 12.345 {`,`, `.`, standard}
+}
+
+func ExampleNormalizeScientific() {
+	s, _ := NormalizeScientific("0.00012")
+	fmt.Println(s)
+	// Output: 1.2e-4
+}
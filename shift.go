@@ -0,0 +1,32 @@
+package decstr
+
+import "strings"
+
+// shiftPoint multiplies the normalized decimal string by 10^k by moving its decimal point
+// k positions to the right (to the left if k is negative), and returns a normalized decimal string.
+// Example:
+//
+//	shiftPoint("12.5", -2) => "0.125"
+//	shiftPoint("-1.25", 3) => "-1250"
+func shiftPoint(normalized string, k int) string {
+	sign, abs := "", normalized
+	if abs[0] == '-' {
+		sign, abs = "-", abs[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(abs, ".")
+	digits := intPart + fracPart
+	p := len(intPart) + k // the new position of the decimal point in digits
+	switch {
+	case p <= 0:
+		intPart, fracPart = "", strings.Repeat("0", -p)+digits
+	case p >= len(digits):
+		intPart, fracPart = digits+strings.Repeat("0", p-len(digits)), ""
+	default:
+		intPart, fracPart = digits[:p], digits[p:]
+	}
+	shifted := string(compose([]byte(intPart), []byte(fracPart)))
+	if shifted == "0" {
+		return shifted
+	}
+	return sign + shifted
+}
@@ -0,0 +1,32 @@
+package decstr
+
+import "testing"
+
+func TestShiftPoint(t *testing.T) {
+	tests := []struct {
+		normalized string
+		k          int
+		want       string
+	}{
+		{"0", 3, "0"},
+		{"0", -3, "0"},
+		{"12.5", 0, "12.5"},
+		{"12.5", -2, "0.125"},
+		{"12.5", -1, "1.25"},
+		{"12.5", 1, "125"},
+		{"12.5", 3, "12500"},
+		{"-1.25", 3, "-1250"},
+		{"-1.25", -4, "-0.000125"},
+		{"100", -2, "1"},
+		{"100", -3, "0.1"},
+		{"0.001", 3, "1"},
+		{"0.001", 2, "0.1"},
+	}
+
+	for _, test := range tests {
+		got := shiftPoint(test.normalized, test.k)
+		if got != test.want {
+			t.Errorf("shiftPoint(%q, %d) = %q, want %q", test.normalized, test.k, got, test.want)
+		}
+	}
+}
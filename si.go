@@ -0,0 +1,21 @@
+package decstr
+
+// siPrefixes maps the SI metric prefixes to their power of 10.
+var siPrefixes = map[string]int{
+	"q": -30, "r": -27, "y": -24, "z": -21, "a": -18, "f": -15, "p": -12, "n": -9,
+	"µ": -6, "μ": -6, "u": -6, // micro sign, greek mu and its ASCII replacement
+	"m": -3, "c": -2, "d": -1,
+	"da": 1, "h": 2, "k": 3, "M": 6, "G": 9, "T": 12,
+	"P": 15, "E": 18, "Z": 21, "Y": 24, "R": 27, "Q": 30,
+}
+
+// ParseSI parses a decimal string followed by an SI metric prefix, like "4.7k", "2,2µ" or "1.5 G",
+// and returns the exact normalized decimal ("4700", "0.0000022", "1500000000").
+// All the SI prefixes from quecto (q) to quetta (Q) are accepted,
+// and micro can be written with the micro sign 'µ', the greek letter 'μ' or 'u'.
+// A string without prefix is accepted.
+// The boolean `ok` is false if the prefix is unknown or if the decimal part
+// is not a valid decimal string (see NormalizeCheck).
+func ParseSI(s string) (value string, ok bool) {
+	return parseScaled(s, siPrefixes)
+}
@@ -0,0 +1,46 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseSI(t *testing.T) {
+	tests := []struct {
+		s     string
+		value string
+		ok    bool
+	}{
+		{"", "", false},
+		{"k", "", false},
+		{"47", "47", true},
+		{"4.7k", "4700", true},
+		{"2.2µ", "0.0000022", true},
+		{"2,2 μ", "0.0000022", true},
+		{"100n", "0.0000001", true},
+		{"1.5 G", "1500000000", true},
+		{"-3.3m", "-0.0033", true},
+		{"1 234,5 da", "12345", true},
+		{"0,5 Q", "500000000000000000000000000000", true},
+		{"1.5 x", "", false},
+		{"1.5 kg", "", false},
+	}
+
+	for _, test := range tests {
+		value, ok := ParseSI(test.s)
+		if value != test.value || ok != test.ok {
+			t.Errorf("ParseSI(%q) = (%q, %v), want (%q, %v)", test.s, value, ok, test.value, test.ok)
+		}
+	}
+}
+
+func ExampleParseSI() {
+	for _, s := range []string{"4.7k", "100n", "2,2 µ"} {
+		value, _ := ParseSI(s)
+		fmt.Println(value)
+	}
+	// Output:
+	// 4700
+	// 0.0000001
+	// 0.0000022
+}
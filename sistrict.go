@@ -0,0 +1,149 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// isSIGroup checks if r is a grouping separator of the SI rules:
+// the space, the thin space U+2009 or the narrow no-break space U+202F.
+func isSIGroup(r rune) bool {
+	return r == ' ' || r == '\u2009' || r == '\u202F'
+}
+
+// validateSI checks that the DecimalFormat follows the SI rules (see SIStrict),
+// and returns an error wrapping ErrInvalidFormat if it does not.
+func validateSI(df DecimalFormat) error {
+	if df.Point != NoSeparator && df.Point != '.' && df.Point != ',' {
+		return fmt.Errorf("%w: %q is not an SI decimal separator", ErrInvalidFormat, df.Point)
+	}
+	if df.Group != NoSeparator && (!isSIGroup(df.Group) || !df.Standard) {
+		return fmt.Errorf("%w: %v does not group the digits by 3 with a space", ErrInvalidFormat, df)
+	}
+	return nil
+}
+
+// parseSI parses the decimal string following the SI rules (see SIStrict).
+func parseSI[T bytestr](decimal T) (normalized T, df DecimalFormat, err error) {
+	s := string(decimal)
+	sign, abs := getSign(s)
+	start := len(trimRight(s, ' ')) - len(abs) // the index of abs in s
+	intPart, fracPart, point := abs, "", NoSeparator
+	if i := strings.IndexAny(abs, ".,"); i >= 0 {
+		intPart, fracPart, point = abs[:i], abs[i+1:], rune(abs[i])
+		if j := strings.IndexAny(fracPart, ".,"); j >= 0 {
+			return normalized, df, newSyntaxError(decimal, start+i+1+j)
+		}
+		if fracPart == "" {
+			return normalized, df, newSyntaxError(decimal, start+i)
+		}
+	}
+	if intPart == "" {
+		// no integer digits, like in "", "-" or ".5"
+		return normalized, df, newSyntaxError(decimal, start)
+	}
+	// the same space must be used on both sides of the decimal separator
+	var space rune
+	intDigits, at := siGroups(intPart, false, &space)
+	if at >= 0 {
+		return normalized, df, newSyntaxError(decimal, start+at)
+	}
+	fracDigits, at := siGroups(fracPart, true, &space)
+	if at >= 0 {
+		return normalized, df, newSyntaxError(decimal, start+len(intPart)+1+at)
+	}
+	number := sign + intDigits
+	if fracDigits != "" {
+		number += "." + fracDigits
+	}
+	df = DecimalFormat{Point: point, Group: NoSeparator, Standard: true}
+	if space != 0 {
+		df.Group = ' '
+	}
+	return T(Normalize(number)), df, nil
+}
+
+// siGroups returns the digits of the integer or fractional part of a decimal following the SI rules,
+// or the index of its first invalid byte. Its groups are separated by space (set by the first
+// separator if it is 0), and have 3 digits, except the first one of the integer part and the last one
+// of the fractional part, that have 1 to 3 digits. A part of 4 digits is not grouped.
+func siGroups(part string, fraction bool, space *rune) (digits string, at int) {
+	var ends []int // the end of each group but the last one
+	for i, r := range part {
+		switch {
+		case '0' <= r && r <= '9':
+		case !isSIGroup(r) || (*space != 0 && r != *space):
+			return "", i
+		default:
+			*space = r
+			ends = append(ends, i)
+		}
+	}
+	if len(ends) == 0 {
+		return part, -1
+	}
+	digits = strings.Map(func(r rune) rune {
+		if r == *space {
+			return -1
+		}
+		return r
+	}, part)
+	if len(digits) == 4 {
+		// a part of 4 digits is not grouped
+		return "", ends[0]
+	}
+	// check the size of each group, from the decimal separator
+	sep := utf8.RuneLen(*space)
+	start := 0
+	for k, end := range append(ends, len(part)) {
+		n := end - start
+		switch {
+		case n == 0:
+			return "", end
+		case n > 3:
+			return "", start + 3
+		case n < 3 && fraction && k < len(ends):
+			return "", end
+		case n < 3 && !fraction && k > 0:
+			return "", start
+		}
+		start = end + sep
+	}
+	return digits, -1
+}
+
+// appendSI appends the normalized decimal converted to the DecimalFormat following
+// the SI rules to dst (see SIStrict). It returns dst unchanged and false if the format
+// does not follow the SI rules, or if the decimal has a fractional part but the format
+// has no decimal separator.
+func appendSI(dst []byte, df DecimalFormat, normalized string) ([]byte, bool) {
+	if validateSI(df) != nil {
+		return dst, false
+	}
+	intPart, fracPart, hasFrac := strings.Cut(normalized, ".")
+	if hasFrac && df.Point == NoSeparator {
+		return dst, false
+	}
+	if intPart[0] == '-' {
+		dst = append(dst, '-')
+		intPart = intPart[1:]
+	}
+	grouped := df.Group != NoSeparator
+	for i := 0; i < len(intPart); i++ {
+		if d := len(intPart) - i; grouped && len(intPart) > 4 && i > 0 && d%3 == 0 {
+			dst = utf8.AppendRune(dst, df.Group)
+		}
+		dst = append(dst, intPart[i])
+	}
+	if hasFrac {
+		dst = utf8.AppendRune(dst, df.Point)
+		for i := 0; i < len(fracPart); i++ {
+			if grouped && len(fracPart) > 4 && i > 0 && i%3 == 0 {
+				dst = utf8.AppendRune(dst, df.Group)
+			}
+			dst = append(dst, fracPart[i])
+		}
+	}
+	return dst, true
+}
@@ -0,0 +1,134 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseSIStrict(t *testing.T) {
+	tests := []struct {
+		decimal string
+		want    string
+		df      DecimalFormat
+	}{
+		{"12 345,678 91", "12345.67891", DecimalFormat{Point: ',', Group: ' ', Standard: true}},
+		{"1234.5678", "1234.5678", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}},
+		{"1,234", "1.234", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}},
+		{"1\u2009234\u2009567", "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}},
+		{"-0,123\u202F45", "-0.12345", DecimalFormat{Point: ',', Group: ' ', Standard: true}},
+		{"1\u2009234\u2009567,5", "1234567.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}},
+		{"1\u202F234\u202F567", "1234567", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}},
+		{"0,5", "0.5", DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}},
+		{"12345", "12345", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}},
+	}
+
+	for _, test := range tests {
+		got, df, err := Parse(test.decimal, WithStrictness(SIStrict))
+		if err != nil || got != test.want || df != test.df {
+			t.Errorf("Parse(%q, SIStrict) = (%q, %v, %v), want (%q, %v, nil)", test.decimal, got, df, err, test.want, test.df)
+		}
+	}
+}
+
+func TestParseSIStrictError(t *testing.T) {
+	tests := []struct {
+		decimal string
+		offset  int
+	}{
+		{"1 234", 1},
+		{"1,234.5", 5},
+		{"12 34", 2},
+		{"1234 567", 3},
+		{"1 234 5", 6},
+		{"0,123 4", 5},
+		{"0,12 345", 4},
+		{".5", 0},
+		{"5,", 1},
+		{"", 0},
+		{"-", 1},
+		{"1'234'567", 1},
+		{"1 234\u2009567", 5},
+		{"12 345,678\u2009912", 10},
+		{"+ 5", 1},
+		{" 5", 0},
+		{"12 kg", 3},
+	}
+
+	for _, test := range tests {
+		_, _, err := Parse(test.decimal, WithStrictness(SIStrict))
+		var se *SyntaxError
+		if !errors.As(err, &se) || se.Offset != test.offset {
+			t.Errorf("Parse(%q, SIStrict) error = %v, want a *SyntaxError at %d", test.decimal, err, test.offset)
+		}
+	}
+}
+
+func TestParseSIStrictOptions(t *testing.T) {
+	tests := []struct {
+		decimal string
+		opts    []Option
+		want    string
+		err     error
+	}{
+		{"1\u2009234\u2009567", []Option{WithMaxGroups(1)}, "", ErrTooLong},
+		{"1\u2009234\u2009567", []Option{WithMaxGroups(2)}, "1234567", nil},
+		{"-0,0", []Option{WithNegativeZero(NegativeZeroReject)}, "", ErrInvalid},
+		{"-0,0", []Option{WithNegativeZero(NegativeZeroKeep)}, "-0", nil},
+		{"007,5", []Option{WithLeadingZeros()}, "007.5", nil},
+	}
+
+	for _, test := range tests {
+		got, _, err := Parse(test.decimal, append([]Option{WithStrictness(SIStrict)}, test.opts...)...)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("Parse(%q, SIStrict, ...) = (%q, %v), want (%q, %v)", test.decimal, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestConvertSIStrict(t *testing.T) {
+	tests := []struct {
+		decimal string
+		df      DecimalFormat
+		want    string
+		ok      bool
+	}{
+		{"1234567.891", DecimalFormat{Point: ',', Group: ' ', Standard: true}, "1 234 567,891", true},
+		{"1234.5678", DecimalFormat{Point: ',', Group: ' ', Standard: true}, "1234,5678", true},
+		{"-0.123456", DecimalFormat{Point: '.', Group: '\u2009', Standard: true}, "-0.123\u2009456", true},
+		{"12345.6", DecimalFormat{Point: '.', Group: '\u202F', Standard: true}, "12\u202F345.6", true},
+		{"12345.6", DecimalFormat{Point: '.', Group: NoSeparator}, "12345.6", true},
+		{"12345", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "12 345", true},
+		{"12345", DecimalFormat{Point: ',', Group: '\u202F', Standard: true}, "12\u202F345", true},
+		{"12345", DecimalFormat{Point: '.', Group: ','}, "0", false},
+		{"12345", DecimalFormat{Point: '.', Group: ' '}, "0", false},
+		{"12345", DecimalFormat{Point: '\'', Group: ' ', Standard: true}, "0", false},
+		{"1.5", DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "0", false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.df.Convert(test.decimal, WithStrictness(SIStrict))
+		if got != test.want || ok != test.ok {
+			t.Errorf("%v.Convert(%q, SIStrict) = (%q, %v), want (%q, %v)", test.df, test.decimal, got, ok, test.want, test.ok)
+		}
+		if generic, ok := Convert(test.df, test.decimal, WithStrictness(SIStrict)); generic != test.want || ok != test.ok {
+			t.Errorf("Convert(%v, %q, SIStrict) = (%q, %v), want (%q, %v)", test.df, test.decimal, generic, ok, test.want, test.ok)
+		}
+	}
+}
+
+func ExampleWithStrictness_sIStrict() {
+	for _, s := range []string{"12 345,678 91", "1234.5678", "1 234"} {
+		n, _, err := Parse(s, WithStrictness(SIStrict))
+		fmt.Println(n, err)
+	}
+	si := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	fmt.Println(si.Convert("1234567.891", WithStrictness(SIStrict)))
+	fmt.Println(si.Convert("1234.5678", WithStrictness(SIStrict)))
+	// Output:
+	// 12345.67891 <nil>
+	// 1234.5678 <nil>
+	//  decstr: invalid decimal string: unexpected ' ' at index 1 in "1 234"
+	// 1 234 567,891 true
+	// 1234,5678 true
+}
@@ -0,0 +1,179 @@
+package decstr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// speller spells out numbers in a given language.
+type speller struct {
+	minus, point string   // the words for '-' and the decimal separator
+	digits       []string // the words for the digits 0-9
+	scales       []string // the words for the powers of 1000 (starting at 1000^1)
+	// group spells out a group of 3 digits (1-999) at the power k of 1000,
+	// with its scale word if any.
+	group func(sp *speller, n, k int) string
+}
+
+// spellers are the supported languages.
+var spellers = map[string]*speller{
+	"en": {
+		minus:  "minus",
+		point:  "point",
+		digits: []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"},
+		scales: []string{"thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+			"sextillion", "septillion", "octillion", "nonillion", "decillion"},
+		group: enGroup,
+	},
+	"fr": {
+		minus:  "moins",
+		point:  "virgule",
+		digits: []string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf"},
+		scales: []string{"mille", "million", "milliard", "billion", "billiard", "trillion",
+			"trilliard", "quadrillion", "quadrilliard", "quintillion", "quintilliard"},
+		group: frGroup,
+	},
+}
+
+// SpellOut returns the decimal string written in words in the given language,
+// "en" (American English) or "fr" (French, traditional spelling).
+// Only the first two letters of lang are used, so "en-US" or "fr_CA" are accepted.
+// The fractional part is spelled out digit by digit.
+// It returns an error wrapping ErrInvalid if the string is not a valid decimal string
+// or if its integer part is too large (more than 36 digits),
+// and an error wrapping ErrLanguage if the language is not supported.
+// Example:
+//
+//	SpellOut("1234.5", "en") => "one thousand two hundred thirty-four point five"
+//	SpellOut("1234.5", "fr") => "mille deux cent trente-quatre virgule cinq"
+func SpellOut(decimal string, lang string) (string, error) {
+	sp, ok := spellers[strings.ToLower(lang[:min(len(lang), 2)])]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrLanguage, lang)
+	}
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, decimal)
+	}
+	var words []string
+	if normalized[0] == '-' {
+		words = append(words, sp.minus)
+		normalized = normalized[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(normalized, ".")
+	if len(intPart) > 3*(len(sp.scales)+1) {
+		return "", fmt.Errorf("%w: %q is too large", ErrInvalid, decimal)
+	}
+	if intPart == "0" {
+		words = append(words, sp.digits[0])
+	}
+	// spell out the groups of 3 digits, starting from the most significant one
+	for k := (len(intPart) - 1) / 3; k >= 0; k-- {
+		end := len(intPart) - 3*k
+		n := 0
+		for _, c := range intPart[max(0, end-3):end] {
+			n = 10*n + int(c-'0')
+		}
+		if n > 0 {
+			words = append(words, sp.group(sp, n, k))
+		}
+	}
+	if fracPart != "" {
+		words = append(words, sp.point)
+		for _, c := range fracPart {
+			words = append(words, sp.digits[c-'0'])
+		}
+	}
+	return strings.Join(words, " "), nil
+}
+
+var (
+	enOnes = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// enGroup spells out in English the group n (1-999) at the power k of 1000.
+func enGroup(sp *speller, n, k int) string {
+	var words []string
+	if h := n / 100; h > 0 {
+		words = append(words, enOnes[h], "hundred")
+	}
+	switch r := n % 100; {
+	case r == 0:
+	case r < 20:
+		words = append(words, enOnes[r])
+	case r%10 == 0:
+		words = append(words, enTens[r/10])
+	default:
+		words = append(words, enTens[r/10]+"-"+enOnes[r%10])
+	}
+	if k > 0 {
+		words = append(words, sp.scales[k-1])
+	}
+	return strings.Join(words, " ")
+}
+
+var (
+	frOnes = []string{"", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize"}
+	frTens = []string{"", "dix", "vingt", "trente", "quarante", "cinquante", "soixante", "soixante", "quatre-vingt", "quatre-vingt"}
+)
+
+// frGroup spells out in French the group n (1-999) at the power k of 1000.
+// "vingt" and "cent" take an 's' when they end the number or are followed by
+// million, milliard… but not when they are followed by mille (which is invariable).
+func frGroup(sp *speller, n, k int) string {
+	if k == 1 && n == 1 {
+		return "mille"
+	}
+	plural := k != 1
+	var words []string
+	h, r := n/100, n%100
+	switch {
+	case h == 1:
+		words = append(words, "cent")
+	case h > 1 && r == 0 && plural:
+		words = append(words, frOnes[h], "cents")
+	case h > 1:
+		words = append(words, frOnes[h], "cent")
+	}
+	if r > 0 {
+		words = append(words, frBelow100(r, plural))
+	}
+	if k > 0 {
+		scale := sp.scales[k-1]
+		if k > 1 && n > 1 {
+			scale += "s"
+		}
+		words = append(words, scale)
+	}
+	return strings.Join(words, " ")
+}
+
+// frBelow100 spells out in French the number n (1-99).
+func frBelow100(n int, plural bool) string {
+	if n <= 16 {
+		return frOnes[n]
+	}
+	t, u := n/10, n%10
+	switch {
+	case t == 1 || t == 7 || t == 9:
+		// 17-19, 70-79 and 90-99 are based on 10-19
+		if t == 1 {
+			return "dix-" + frOnes[u]
+		}
+		if t == 7 && u == 1 {
+			return "soixante et onze"
+		}
+		return frTens[t] + "-" + frBelow100(10+u, plural)
+	case u == 0 && t == 8 && plural:
+		return "quatre-vingts"
+	case u == 0:
+		return frTens[t]
+	case u == 1 && t != 8:
+		return frTens[t] + " et un"
+	default:
+		return frTens[t] + "-" + frOnes[u]
+	}
+}
@@ -0,0 +1,69 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSpellOut(t *testing.T) {
+	tests := []struct {
+		decimal string
+		lang    string
+		want    string
+		err     error
+	}{
+		{"0", "en", "zero", nil},
+		{"7", "en", "seven", nil},
+		{"15", "en", "fifteen", nil},
+		{"40", "en", "forty", nil},
+		{"99", "en", "ninety-nine", nil},
+		{"100", "en", "one hundred", nil},
+		{"1234.5", "en", "one thousand two hundred thirty-four point five", nil},
+		{"-0.05", "en-US", "minus zero point zero five", nil},
+		{"1 000 001", "en", "one million one", nil},
+		{"2000000000", "EN", "two billion", nil},
+		{"0", "fr", "zéro", nil},
+		{"16", "fr", "seize", nil},
+		{"17", "fr", "dix-sept", nil},
+		{"21", "fr", "vingt et un", nil},
+		{"71", "fr", "soixante et onze", nil},
+		{"77", "fr", "soixante-dix-sept", nil},
+		{"80", "fr", "quatre-vingts", nil},
+		{"81", "fr", "quatre-vingt-un", nil},
+		{"91", "fr", "quatre-vingt-onze", nil},
+		{"99", "fr", "quatre-vingt-dix-neuf", nil},
+		{"200", "fr", "deux cents", nil},
+		{"201", "fr", "deux cent un", nil},
+		{"1000", "fr", "mille", nil},
+		{"1234,5", "fr", "mille deux cent trente-quatre virgule cinq", nil},
+		{"80000", "fr", "quatre-vingt mille", nil},
+		{"200000", "fr", "deux cent mille", nil},
+		{"80000000", "fr", "quatre-vingts millions", nil},
+		{"1000000", "fr", "un million", nil},
+		{"2001000", "fr_CA", "deux millions mille", nil},
+		{"3000000000", "fr", "trois milliards", nil},
+		{"-12.05", "fr", "moins douze virgule zéro cinq", nil},
+		{"12", "de", "", ErrLanguage},
+		{"12", "", "", ErrLanguage},
+		{"1,234", "en", "", ErrInvalid},
+		{"1000000000000000000000000000000000000", "en", "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		got, err := SpellOut(test.decimal, test.lang)
+		if got != test.want || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("SpellOut(%q, %q) = (%q, %v), want (%q, %v)", test.decimal, test.lang, got, err, test.want, test.err)
+		}
+	}
+}
+
+func ExampleSpellOut() {
+	en, _ := SpellOut("1 234,5", "en")
+	fmt.Println(en)
+	fr, _ := SpellOut("1 234,5", "fr")
+	fmt.Println(fr)
+	// Output:
+	// one thousand two hundred thirty-four point five
+	// mille deux cent trente-quatre virgule cinq
+}
@@ -0,0 +1,183 @@
+package decstr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"unicode/utf8"
+)
+
+// normalizingReader is the io.Reader returned by NewNormalizingReader.
+type normalizingReader struct {
+	decimalBuffer
+	r    io.Reader
+	size int   // the size of the chunks read from r
+	err  error // the error returned by r (io.EOF at the end)
+	ctx  context.Context
+}
+
+// decimalBuffer replaces the decimals of a stream, handling the decimals split across its chunks.
+type decimalBuffer struct {
+	in   []byte // the pending input, starting with kept already processed bytes
+	kept int    // the number of bytes at the start of in kept as context (up to utf8.UTFMax)
+	out  []byte // the processed output not yet consumed
+	// replace appends the replacement of the decimal (with its normalized value) to out
+	replace func(out, decimal, normalized []byte) []byte
+}
+
+// NewNormalizingReader returns a reader that normalizes the decimals found in the data read from r
+// (e.g. "total: 1 234,50 €" becomes "total: 1234.5 €"), leaving everything else untouched.
+// A decimal is a run of digits and separators that is not glued to a word
+// (as in "v1.2" or "12kg") nor part of a date or a time ("2020-01-05", "12:30"),
+// and is a valid, non ambiguous, decimal string.
+// If the whole run is not valid, its longest valid prefix ending before a space is used:
+// "1.5 2.5" is normalized as two decimals.
+// Decimals split across the chunks read from r are handled, so a run of digits is
+// kept in memory until it ends.
+// The read chunks size can be set with WithBufferSize.
+func NewNormalizingReader(r io.Reader, opts ...Option) io.Reader {
+	return NewNormalizingReaderContext(context.Background(), r, opts...)
+}
+
+// NewNormalizingReaderContext is like NewNormalizingReader, but the returned reader
+// stops reading from r and returns the error of ctx once it is done.
+func NewNormalizingReaderContext(ctx context.Context, r io.Reader, opts ...Option) io.Reader {
+	o := newOptions(opts)
+	return &normalizingReader{
+		decimalBuffer: decimalBuffer{replace: func(out, _, normalized []byte) []byte {
+			return append(out, normalized...)
+		}},
+		r:    r,
+		size: o.bufferSize,
+		ctx:  ctx,
+	}
+}
+
+// Read implements io.Reader.
+func (nr *normalizingReader) Read(p []byte) (int, error) {
+	for len(nr.out) == 0 {
+		if nr.err != nil {
+			return 0, nr.err
+		}
+		if err := nr.ctx.Err(); err != nil {
+			return 0, err
+		}
+		// read the next chunk
+		n := len(nr.in)
+		nr.in = slices.Grow(nr.in, nr.size)
+		m, err := nr.r.Read(nr.in[n : n+nr.size])
+		nr.in = nr.in[:n+m]
+		if err != nil {
+			nr.err = err
+		}
+		nr.process(err != nil)
+	}
+	n := copy(p, nr.out)
+	nr.out = nr.out[n:]
+	return n, nil
+}
+
+// process replaces the decimals of the pending input in b.in and appends it to b.out.
+// If atEOF is false, the decimals that may continue in the next chunk are kept in b.in.
+func (b *decimalBuffer) process(atEOF bool) {
+	i := b.kept
+	for i < len(b.in) {
+		start, end, normalized, _ := nextDecimal(b.in, i, atEOF)
+		b.out = append(b.out, b.in[i:start]...)
+		i = start
+		if end < 0 {
+			break
+		}
+		b.out = b.replace(b.out, b.in[start:end], normalized)
+		i = end
+	}
+	// keep the unprocessed bytes and the rune before them as context
+	b.kept = min(i, utf8.UTFMax)
+	b.in = append(b.in[:0], b.in[i-b.kept:]...)
+}
+
+// convertingWriter is the io.WriteCloser returned by NewConvertingWriter.
+type convertingWriter struct {
+	decimalBuffer
+	w   io.Writer
+	err error // the first error returned by w
+}
+
+// NewConvertingWriter returns a writer that converts the decimals found in the data written
+// through it to the DecimalFormat, and writes the result to w, leaving everything else untouched
+// (e.g. "total: 1234.5 €" becomes "total: 1 234,50 €" with WithMinScale(2)).
+// The decimals are found like with NewNormalizingReader, and converted like with ReplaceAll,
+// using the options; the decimals that cannot be converted are written unchanged.
+// As a decimal may continue in the next write, a run of digits is kept in memory until it ends,
+// so Close must be called to write the end of the data. Close does not close w.
+func NewConvertingWriter(w io.Writer, df DecimalFormat, opts ...Option) io.WriteCloser {
+	return &convertingWriter{
+		decimalBuffer: decimalBuffer{replace: func(out, decimal, normalized []byte) []byte {
+			converted, ok := df.Convert(string(normalized), opts...)
+			if !ok {
+				return append(out, decimal...)
+			}
+			// keep the explicit '+' sign, dropped by Convert
+			if decimal[0] == '+' && converted[0] != '+' {
+				out = append(out, '+')
+			}
+			return append(out, converted...)
+		}},
+		w: w,
+	}
+}
+
+// Write implements io.Writer. It returns the first error returned by the underlying writer.
+func (cw *convertingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	cw.in = append(cw.in, p...)
+	cw.process(false)
+	if err := cw.flush(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes the pending data to the underlying writer, without closing it.
+func (cw *convertingWriter) Close() error {
+	if cw.err != nil {
+		return cw.err
+	}
+	cw.process(true)
+	cw.in, cw.kept = cw.in[:0], 0
+	return cw.flush()
+}
+
+// flush writes the processed output to the underlying writer.
+func (cw *convertingWriter) flush() error {
+	if len(cw.out) == 0 {
+		return nil
+	}
+	_, cw.err = cw.w.Write(cw.out)
+	cw.out = cw.out[:0]
+	return cw.err
+}
+
+// ConvertTo writes the decimal converted to the DecimalFormat to w (see Convert for the formatting rules).
+// It returns the number of bytes written and the error returned by w, if any.
+// If the DecimalFormat is not valid, it returns an error wrapping ErrInvalidFormat,
+// and if the input is not a valid decimal string (or has a fractional part but the format
+// has no decimal separator), an error wrapping ErrInvalid. In both cases nothing is written.
+func (df DecimalFormat) ConvertTo(w io.Writer, decimal string) (int, error) {
+	if err := df.Validate(); err != nil {
+		return 0, err
+	}
+	normalized, ok := toNormalized(decimal)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalid, decimal)
+	}
+	var arr [64]byte // enough for most decimals, avoids an allocation
+	buf, ok := appendNormalized(arr[:0], df, normalized)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has a fractional part but the format %v has no decimal separator", ErrInvalid, decimal, df)
+	}
+	return w.Write(buf)
+}
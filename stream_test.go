@@ -0,0 +1,190 @@
+package decstr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNormalizingReader(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", ""},
+		{"no decimals here", "no decimals here"},
+		{"1 234,50", "1234.5"},
+		{"total: 1 234,50 € (-12.30)", "total: 1234.5 € (-12.3)"},
+		{"a,1'234'567.8,b", "a,1234567.8,b"},
+		{"1.5 2.5 3", "1.5 2.5 3"},
+		{"1,5 2,50 +3", "1.5 2.5 3"},
+		{"1 234 5", "1234 5"},
+		{"1,234 and 1.234", "1,234 and 1.234"},
+		{"1,234 2,5", "1,234 2.5"},
+		{"v1.2.3 12kg 2020-01-05 12:30 x1,5", "v1.2.3 12kg 2020-01-05 12:30 x1,5"},
+		{"5-3=2,0", "5-3=2"},
+		{"1,234·56\n007", "1234.56\n7"},
+		{"end 12,50", "end 12.5"},
+		{"end 12,", "end 12,"},
+		{"end -", "end -"},
+		{"café1,5 x", "café1,5 x"},
+		{"日本12,5 x", "日本12,5 x"},
+		{"€1,5 x", "€1.5 x"},
+	}
+
+	for _, test := range tests {
+		for _, size := range []int{1, 2, 3, 5, 4096} {
+			r := NewNormalizingReader(iotest.OneByteReader(strings.NewReader(test.text)), WithBufferSize(size))
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Errorf("NormalizingReader(%q) error: %v", test.text, err)
+			}
+			if string(got) != test.want {
+				t.Errorf("NormalizingReader(%q) with buffer size %d = %q, want %q", test.text, size, got, test.want)
+			}
+		}
+		got, err := io.ReadAll(NewNormalizingReader(strings.NewReader(test.text)))
+		if err != nil || string(got) != test.want {
+			t.Errorf("NormalizingReader(%q) = (%q, %v), want %q", test.text, got, err, test.want)
+		}
+	}
+}
+
+func TestNormalizingReaderError(t *testing.T) {
+	r := NewNormalizingReader(iotest.TimeoutReader(strings.NewReader("1 234,5 and more")))
+	got, err := io.ReadAll(r)
+	if err != iotest.ErrTimeout {
+		t.Errorf("NormalizingReader error = %v, want %v", err, iotest.ErrTimeout)
+	}
+	if string(got) != "1234.5 and more" {
+		t.Errorf("NormalizingReader = %q, want %q", got, "1234.5 and more")
+	}
+}
+
+func TestNormalizingReaderContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewNormalizingReaderContext(ctx, strings.NewReader("1 234,5 and 6 789,5"), WithBufferSize(8))
+	buf := make([]byte, 4)
+	if _, err := r.Read(buf); err != nil {
+		t.Fatalf("NormalizingReader.Read error = %v, want nil", err)
+	}
+	cancel()
+	// the pending output is still returned, then the context error
+	_, err := io.ReadAll(r)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("NormalizingReader error after cancel = %v, want %v", err, context.Canceled)
+	}
+}
+
+func ExampleNewNormalizingReader() {
+	r := NewNormalizingReader(strings.NewReader("price: 1 234,50 €\n"))
+	io.Copy(os.Stdout, r)
+	// Output: price: 1234.5 €
+}
+
+func TestConvertTo(t *testing.T) {
+	tests := []struct {
+		df      DecimalFormat
+		decimal string
+		want    string
+		err     error
+	}{
+		{DecimalFormat{Point: ',', Group: ' ', Standard: true}, "-1234567.891", "-1 234 567,891", nil},
+		{DecimalFormat{Point: '.', Group: ',', Standard: false}, "1 234 567,8", "12,34,567.8", nil},
+		{DecimalFormat{Point: ',', Group: ' ', Standard: true}, "1,234", "", ErrInvalid},
+		{DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "1.5", "", ErrInvalid},
+		{DecimalFormat{Point: ',', Group: ',', Standard: true}, "1", "", ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		sb := strings.Builder{}
+		n, err := test.df.ConvertTo(&sb, test.decimal)
+		if sb.String() != test.want || n != len(test.want) || !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("(%v).ConvertTo(w, %q) = (%d, %v) and wrote %q, want (%d, %v) and %q", test.df, test.decimal, n, err, sb.String(), len(test.want), test.err, test.want)
+		}
+	}
+	// the error of the writer is returned
+	if _, err := (DecimalFormat{Point: '.'}).ConvertTo(errWriter{}, "12"); err != iotest.ErrTimeout {
+		t.Errorf("ConvertTo(errWriter) = %v, want %v", err, iotest.ErrTimeout)
+	}
+}
+
+// errWriter is a writer that always fails.
+type errWriter struct{}
+
+func (errWriter) Write(p []byte) (int, error) {
+	return 0, iotest.ErrTimeout
+}
+
+func ExampleDecimalFormat_ConvertTo() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	df.ConvertTo(os.Stdout, "1234567.89")
+	// Output: 1.234.567,89
+}
+
+func TestConvertingWriter(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	tests := []string{
+		"",
+		"no decimals here",
+		"1234.5",
+		"total: 1234.5 € (-12.30)",
+		"a,1'234'567.8,b",
+		"1.5 2.5 +3",
+		"1,234 and 1.234",
+		"v1.2.3 12kg 2020-01-05 12:30 x1,5",
+		"5-3=2,0",
+		"1,234·56\n007",
+		"end 12,",
+		"end -",
+		"café1.5 x",
+		"€1.5 x",
+	}
+
+	for _, text := range tests {
+		want := df.ReplaceAll(text)
+		for _, size := range []int{1, 2, 3, 5, len(text) + 1} {
+			var sb strings.Builder
+			w := NewConvertingWriter(&sb, df)
+			for s := text; len(s) > 0; {
+				n := min(size, len(s))
+				if m, err := w.Write([]byte(s[:n])); m != n || err != nil {
+					t.Errorf("ConvertingWriter(%q).Write = (%d, %v), want (%d, nil)", text, m, err, n)
+				}
+				s = s[n:]
+			}
+			if err := w.Close(); err != nil || sb.String() != want {
+				t.Errorf("ConvertingWriter(%q) with writes of %d bytes = (%q, %v), want %q", text, size, sb.String(), err, want)
+			}
+		}
+	}
+
+	// the decimals that cannot be converted are kept, and the options are used
+	var sb strings.Builder
+	w := NewConvertingWriter(&sb, DecimalFormat{Group: ','}, WithPlusSign())
+	io.WriteString(w, "1 234 and 2,5 +7")
+	w.Close()
+	if want := "+1,234 and 2,5 +7"; sb.String() != want {
+		t.Errorf("ConvertingWriter without decimal separator = %q, want %q", sb.String(), want)
+	}
+	// the error of the writer is returned
+	w = NewConvertingWriter(errWriter{}, df)
+	if _, err := io.WriteString(w, "12.5 and more"); err != iotest.ErrTimeout {
+		t.Errorf("ConvertingWriter(errWriter).Write = %v, want %v", err, iotest.ErrTimeout)
+	}
+	if err := w.Close(); err != iotest.ErrTimeout {
+		t.Errorf("ConvertingWriter(errWriter).Close = %v, want %v", err, iotest.ErrTimeout)
+	}
+}
+
+func ExampleNewConvertingWriter() {
+	w := NewConvertingWriter(os.Stdout, DecimalFormat{Point: ',', Group: '.', Standard: true}, WithMinScale(2))
+	fmt.Fprintf(w, "total: %v €\n", 1234.5)
+	w.Close()
+	// Output: total: 1.234,50 €
+}
@@ -0,0 +1,131 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// detectTag is the value of the decstr struct tag asking for the detection of the format.
+const detectTag = "detect"
+
+// UnmarshalStruct normalizes in place the decimal fields of the struct pointed to by v,
+// like the values of a web form bound to a struct of strings.
+// The decimal fields are the string fields with a decstr tag:
+//   - `decstr:"detect"` normalizes the value using its detected format, like Parse;
+//   - `decstr:"de-DE"` normalizes the value written in the format of the locale (see FormatForLocale).
+//     The grouping separator may be omitted, and the ambiguous values like "1.234" are resolved
+//     with the locale format, but a value using another format, like "1.5" for "de-DE", is
+//     rejected with an error wrapping ErrMismatch.
+//
+// The blank values and the Normalized fields (already normalized) are kept unchanged,
+// and the struct fields without tag are processed recursively.
+// The options are passed to Parse. The first error is returned, with the name of its field,
+// and the fields processed before it stay normalized.
+func UnmarshalStruct(v any, opts ...Option) error {
+	return walkStruct(v, func(value, tag string) (string, error) {
+		if tag == detectTag {
+			normalized, _, err := Parse(value, opts...)
+			return normalized, err
+		}
+		df, err := FormatForLocale(tag)
+		if err != nil {
+			return "", err
+		}
+		return parseIn(df, value, opts)
+	})
+}
+
+// MarshalStruct converts in place the decimal fields of the struct pointed to by v
+// to the format of their locale, like "1.234,5" for the value "1234.5" and the tag `decstr:"de-DE"`.
+// The decimal fields are the ones of UnmarshalStruct, and their values must be decimal strings
+// (usually normalized ones, so "1.234" is read as normalized and not as ambiguous). The fields with the tag `decstr:"detect"` are normalized,
+// and the Normalized fields, that cannot hold a converted value, are kept unchanged.
+// The options are passed to DecimalFormat.Convert.
+func MarshalStruct(v any, opts ...Option) error {
+	return walkStruct(v, func(value, tag string) (string, error) {
+		normalized, ok := toNormalized(value)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalid, value)
+		}
+		if tag == detectTag {
+			return normalized, nil
+		}
+		df, err := FormatForLocale(tag)
+		if err != nil {
+			return "", err
+		}
+		converted, ok := df.Convert(normalized, opts...)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalid, value)
+		}
+		return converted, nil
+	})
+}
+
+// normalizedType is the type of the Normalized fields.
+var normalizedType = reflect.TypeOf(Normalized{})
+
+// walkStruct replaces the values of the decimal fields of the struct pointed to by v
+// by the result of f applied to their value and tag.
+func walkStruct(v any, f func(value, tag string) (string, error)) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decstr: %T is not a pointer to a struct", v)
+	}
+	return walkFields(rv.Elem(), "", f)
+}
+
+// walkFields is walkStruct for the struct value rv, whose fields are named with prefix.
+func walkFields(rv reflect.Value, prefix string, f func(value, tag string) (string, error)) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field, fv := rt.Field(i), rv.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, tagged := field.Tag.Lookup("decstr")
+		switch {
+		case field.Type == normalizedType:
+			// already normalized
+		case !tagged && fv.Kind() == reflect.Struct:
+			if err := walkFields(fv, prefix+field.Name+".", f); err != nil {
+				return err
+			}
+		case !tagged || tag == "-":
+			// not a decimal field
+		case fv.Kind() != reflect.String:
+			return fmt.Errorf("decstr: field %s%s: the decstr tag needs a string field, not %v", prefix, field.Name, field.Type)
+		case strings.TrimSpace(fv.String()) != "":
+			value, err := f(fv.String(), tag)
+			if err != nil {
+				return fmt.Errorf("decstr: field %s%s: %w", prefix, field.Name, err)
+			}
+			fv.SetString(value)
+		}
+	}
+	return nil
+}
+
+// parseIn normalizes the decimal string written in the format df, with an optional grouping
+// separator. The ambiguous strings, like "1,234", are resolved with df.
+func parseIn(df DecimalFormat, decimal string, opts []Option) (string, error) {
+	normalized, got, err := Parse(decimal, opts...)
+	if errors.Is(err, ErrAmbiguous) {
+		trimmed := strings.TrimSpace(decimal)
+		switch sep, _ := ambiguousSeparator(trimmed); sep {
+		case df.Point:
+			return Normalize(strings.Replace(trimmed, string(sep), "·", 1)), nil
+		case df.Group:
+			return Normalize(strings.Replace(trimmed, string(sep), "", 1)), nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	if !got.fits(df) {
+		return "", fmt.Errorf("%w %v: %q is written in the format %v", ErrMismatch, df, decimal, got)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,118 @@
+package decstr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type invoice struct {
+	Number  string
+	Amount  string     `decstr:"de-DE"`
+	Price   string     `decstr:"en-US"`
+	Total   Normalized `decstr:"detect"`
+	Comment string     `decstr:"-"`
+	Address struct {
+		Zip string
+	}
+	Line struct {
+		Quantity string `decstr:"fr-FR"`
+	}
+	unexported string `decstr:"detect"`
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	tests := []struct {
+		amount, price string
+		wantAmount    string
+		wantPrice     string
+		err           error
+	}{
+		{"1.234,5", "1,234.5", "1234.5", "1234.5", nil},
+		{"1234,5", "1234.5", "1234.5", "1234.5", nil},
+		{"1.234", "1,234", "1234", "1234", nil},
+		{"1,234", "1.234", "1.234", "1.234", nil},
+		{"", " ", "", " ", nil},
+		{"1.5", "1", "", "", ErrMismatch},
+		{"1 234,5", "1", "", "", ErrMismatch},
+		{"abc", "1", "", "", ErrInvalid},
+	}
+
+	for _, test := range tests {
+		v := invoice{Number: "0012", Amount: test.amount, Price: test.price, Total: Normalized{"1234.5"}, Comment: "1,5"}
+		v.Address.Zip = "01234"
+		v.Line.Quantity = "2,50"
+		err := UnmarshalStruct(&v)
+		if !errors.Is(err, test.err) || (test.err == nil && err != nil) {
+			t.Errorf("UnmarshalStruct(%q, %q) error = %v, want %v", test.amount, test.price, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if v.Amount != test.wantAmount || v.Price != test.wantPrice {
+			t.Errorf("UnmarshalStruct(%q, %q) = (%q, %q), want (%q, %q)", test.amount, test.price, v.Amount, v.Price, test.wantAmount, test.wantPrice)
+		}
+		if v.Total.String() != "1234.5" || v.Line.Quantity != "2.5" {
+			t.Errorf("UnmarshalStruct() Total = %v, Line.Quantity = %q, want %q and %q", v.Total, v.Line.Quantity, "1234.5", "2.5")
+		}
+		if v.Number != "0012" || v.Comment != "1,5" || v.Address.Zip != "01234" {
+			t.Errorf("UnmarshalStruct() changed the fields without decimal tag: %+v", v)
+		}
+	}
+}
+
+func TestUnmarshalStructErrors(t *testing.T) {
+	if err := UnmarshalStruct(invoice{}); err == nil {
+		t.Errorf("UnmarshalStruct(invoice{}) error = nil, want an error")
+	}
+	var unknown struct {
+		A string `decstr:"xx-XX"`
+	}
+	unknown.A = "1"
+	if err := UnmarshalStruct(&unknown); !errors.Is(err, ErrLanguage) {
+		t.Errorf("UnmarshalStruct() error = %v, want %v", err, ErrLanguage)
+	}
+	var notString struct {
+		A float64 `decstr:"detect"`
+	}
+	if err := UnmarshalStruct(&notString); err == nil {
+		t.Errorf("UnmarshalStruct() with a float64 field error = nil, want an error")
+	}
+	v := invoice{Amount: "1", Price: "1"}
+	v.Line.Quantity = "x"
+	if err := UnmarshalStruct(&v); err == nil || !strings.HasPrefix(err.Error(), "decstr: field Line.Quantity:") {
+		t.Errorf("UnmarshalStruct() error = %v, want an error for the field Line.Quantity", err)
+	}
+}
+
+func TestMarshalStruct(t *testing.T) {
+	v := invoice{Number: "0012", Amount: "1234.5", Price: "-1234567", Total: Normalized{"1234.5"}, Comment: "1.5"}
+	v.Line.Quantity = "1.234" // normalized, not ambiguous
+	if err := MarshalStruct(&v, WithMinScale(2)); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount != "1.234,50" || v.Price != "-1,234,567.00" || v.Total.String() != "1234.5" || v.Line.Quantity != "1,234" {
+		t.Errorf("MarshalStruct() = %+v", v)
+	}
+	if v.Number != "0012" || v.Comment != "1.5" {
+		t.Errorf("MarshalStruct() changed the fields without decimal tag: %+v", v)
+	}
+	v.Amount = "abc"
+	if err := MarshalStruct(&v); !errors.Is(err, ErrInvalid) {
+		t.Errorf("MarshalStruct() error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func ExampleUnmarshalStruct() {
+	form := struct {
+		Price    string `decstr:"de-DE"`
+		Discount string `decstr:"detect"`
+	}{Price: "1.234,50", Discount: "0,15"}
+	if err := UnmarshalStruct(&form); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(form.Price, form.Discount)
+	// Output: 1234.5 0.15
+}
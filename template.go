@@ -0,0 +1,62 @@
+package decstr
+
+// FuncMap returns the decstr functions to be used with text/template or html/template
+// (the returned map can be passed directly to the Funcs method of both packages).
+//   - normalize: returns the normalized decimal string (see Normalize).
+//   - detect: returns the DecimalFormat of a decimal string (see DetectFormat).
+//   - convert: converts a decimal string to the given DecimalFormat (see Convert).
+//
+// All functions return an error if the input is not a valid decimal string,
+// which stops the template execution.
+// Example:
+//
+//	{{ convert .Format .Amount }}
+func FuncMap() map[string]any {
+	return map[string]any{
+		"normalize": tmplNormalize,
+		"detect":    tmplDetect,
+		"convert": func(df DecimalFormat, decimal string) (string, error) {
+			return df.tmplConvert(decimal)
+		},
+	}
+}
+
+// FuncMap returns the same functions as the package level FuncMap,
+// except that convert is bound to df and takes only the decimal string.
+// Example:
+//
+//	{{ .Amount | convert }}
+func (df DecimalFormat) FuncMap() map[string]any {
+	return map[string]any{
+		"normalize": tmplNormalize,
+		"detect":    tmplDetect,
+		"convert":   df.tmplConvert,
+	}
+}
+
+// tmplNormalize is the template version of Normalize.
+func tmplNormalize(decimal string) (string, error) {
+	normalized, ok := NormalizeCheck(decimal)
+	if !ok {
+		return "", ErrInvalid
+	}
+	return normalized, nil
+}
+
+// tmplDetect is the template version of DetectFormat.
+func tmplDetect(decimal string) (DecimalFormat, error) {
+	df, ok := DetectFormat(decimal)
+	if !ok {
+		return df, ErrInvalid
+	}
+	return df, nil
+}
+
+// tmplConvert is the template version of Convert.
+func (df DecimalFormat) tmplConvert(decimal string) (string, error) {
+	converted, ok := df.Convert(decimal)
+	if !ok {
+		return "", ErrInvalid
+	}
+	return converted, nil
+}
@@ -0,0 +1,58 @@
+package decstr
+
+import (
+	htmltemplate "html/template"
+	"os"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		data any
+		want string
+		err  bool
+	}{
+		{`{{ normalize . }}`, "1 234,50", "1234.5", false},
+		{`{{ detect . }}`, "1 234,50", "{`,`, ` `, standard}", false},
+		{`{{ convert .F .D }}`, struct {
+			F DecimalFormat
+			D string
+		}{DecimalFormat{Point: ',', Group: '.', Standard: true}, "1234.5"}, "1.234,5", false},
+		{`{{ normalize . }}`, "1,234", "", true},
+		{`{{ detect . }}`, "abc", "", true},
+	}
+
+	for _, test := range tests {
+		tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(test.tmpl))
+		sb := strings.Builder{}
+		err := tmpl.Execute(&sb, test.data)
+		if (err != nil) != test.err || (err == nil && sb.String() != test.want) {
+			t.Errorf("template %q with %v = (%q, %v), want (%q, error: %v)", test.tmpl, test.data, sb.String(), err, test.want, test.err)
+		}
+	}
+}
+
+func TestDecimalFormatFuncMap(t *testing.T) {
+	df := DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	tmpl := htmltemplate.Must(htmltemplate.New("").Funcs(df.FuncMap()).Parse(`<td>{{ . | convert }}</td>`))
+	sb := strings.Builder{}
+	if err := tmpl.Execute(&sb, "1234567.8"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sb.String(), "<td>1 234 567,8</td>"; got != want {
+		t.Errorf("template = %q, want %q", got, want)
+	}
+	if err := tmpl.Execute(&sb, "1 2"); err == nil {
+		t.Errorf("template with invalid decimal should fail")
+	}
+}
+
+func ExampleDecimalFormat_FuncMap() {
+	df := DecimalFormat{Point: ',', Group: '.', Standard: true}
+	tmpl := template.Must(template.New("").Funcs(df.FuncMap()).Parse("Total: {{ convert . }} €\n"))
+	tmpl.Execute(os.Stdout, "1234.5")
+	// Output: Total: 1.234,5 €
+}
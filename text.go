@@ -0,0 +1,300 @@
+package decstr
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isSeparatorAt checks if text[i] starts a separator that can be part of a decimal
+// (',', '.', '\”, '_', '·' or a space, see isSpaceAt) and returns its length in bytes.
+func isSeparatorAt[T bytestr](text T, i int) (n int, ok bool) {
+	switch text[i] {
+	case ',', '.', '\'', '_':
+		return 1, true
+	case 0xC2:
+		if i+1 < len(text) && text[i+1] == 0xB7 {
+			return 2, true
+		}
+	}
+	return isSpaceAt(text, i)
+}
+
+// isSpaceAt checks if text[i] starts a grouping space, ' ' or a Unicode space
+// like the no-break space (see isUnicodeSpace), and returns its length in bytes.
+func isSpaceAt[T bytestr](text T, i int) (n int, ok bool) {
+	switch {
+	case text[i] == ' ':
+		return 1, true
+	case text[i] < utf8.RuneSelf:
+		return 0, false
+	}
+	r, n := utf8.DecodeRuneInString(string(text[i:min(len(text), i+utf8.UTFMax)]))
+	return n, isUnicodeSpace(r)
+}
+
+// isDigitAt checks if text[i] exists and is an ASCII digit.
+func isDigitAt[T bytestr](text T, i int) bool {
+	return 0 <= i && i < len(text) && '0' <= text[i] && text[i] <= '9'
+}
+
+// runEnd returns the end of the run of digits and separators that starts at i.
+// A run may start with a sign, is composed of digits, and each separator in it
+// is followed by a digit.
+func runEnd[T bytestr](text T, i int) int {
+	if text[i] == '-' || text[i] == '+' {
+		i++
+	}
+	for i < len(text) {
+		if isDigitAt(text, i) {
+			i++
+			continue
+		}
+		n, ok := isSeparatorAt(text, i)
+		if !ok || !isDigitAt(text, i+n) {
+			break
+		}
+		i += n
+	}
+	return i
+}
+
+// isWordRune checks if the rune can be part of a word, so a decimal cannot be glued to it.
+// The '_' is a word rune, except between digits where it is a grouping separator:
+// it is then part of the run of the decimal (see runEnd), that is never glued to itself.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// boundaryBefore checks if a decimal can start at text[i], based on the preceding rune.
+// A decimal cannot follow a word rune, nor one of ".'-+/:" (e.g. in "v1.2", "2020-01-05" or "12:30").
+func boundaryBefore[T bytestr](text T, i int) bool {
+	if i == 0 {
+		return true
+	}
+	switch text[i-1] {
+	case '.', '\'', '-', '+', '/', ':':
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(string(text[max(0, i-utf8.UTFMax):i]))
+	return !isWordRune(r)
+}
+
+// boundaryAfter checks if a decimal can end at text[i], based on the following rune.
+// A decimal cannot be followed by a word rune, nor by one of "-/:" followed by a digit.
+func boundaryAfter[T bytestr](text T, i int) bool {
+	if i >= len(text) {
+		return true
+	}
+	switch text[i] {
+	case '-', '/', ':':
+		if isDigitAt(text, i+1) {
+			return false
+		}
+	}
+	r, _ := utf8.DecodeRuneInString(string(text[i:min(len(text), i+utf8.UTFMax)]))
+	return !isWordRune(r)
+}
+
+// nextDecimal looks for the next decimal in text, starting at the byte offset from
+// (the bytes before from are only used to check the boundary of the decimal).
+// It returns the bounds [start, end) of the decimal, its normalized value and its format.
+// If there is no decimal, start and end are len(text).
+//
+// A decimal is a run of digits and separators (see runEnd), not glued to a word,
+// that is a valid decimal string. If the whole run is not valid, its longest valid
+// prefix ending before a space is used (e.g. "1.5" in "1.5 2.5"); otherwise the run
+// up to its first space is skipped.
+//
+// If atEOF is false and a run reaches the end of text (or is too close to it to be
+// sure that it is complete), end is -1 and start is the beginning of this run:
+// the caller should call nextDecimal again with more data.
+func nextDecimal[T bytestr](text T, from int, atEOF bool) (start, end int, normalized T, df DecimalFormat) {
+	i := from
+	for i < len(text) {
+		c := text[i]
+		isSign := c == '-' || c == '+'
+		if !isDigitAt(text, i) && !isSign {
+			i++
+			continue
+		}
+		if isSign && !atEOF && i+1 == len(text) {
+			return i, -1, normalized, df
+		}
+		if isSign && !isDigitAt(text, i+1) {
+			i++
+			continue
+		}
+		// a run starts at i
+		end := runEnd(text, i)
+		// a separator followed by a digit may still come (e.g. "\u202F5" is 4 bytes long)
+		if !atEOF && end+utf8.UTFMax > len(text) {
+			return i, -1, normalized, df
+		}
+		if !boundaryBefore(text, i) || !boundaryAfter(text, end) {
+			i = end
+			continue
+		}
+		// try the whole run, then its prefixes ending before a space
+		first := end // the end of the first chunk of the run (before the first space)
+		for e := end; e > i; e = lastSpace(text, i, e) {
+			if normalized, df, ok := detectAndNormalize(text[i:e]); ok {
+				return i, e, normalized, df
+			}
+			first = e
+		}
+		i = first
+	}
+	return len(text), len(text), normalized, df
+}
+
+// lastSpace returns the position of the last space (see isSpaceAt) in text[start:end]
+// that is followed by a digit, or start if there is none.
+func lastSpace[T bytestr](text T, start, end int) int {
+	for i := end - 1; i > start; i-- {
+		if n, ok := isSpaceAt(text, i); ok && isDigitAt(text, i+n) {
+			return i
+		}
+	}
+	return start
+}
+
+// Match is a decimal found in a text.
+type Match struct {
+	Start, End int           // the byte offsets of the decimal in the text: text[Start:End]
+	Format     DecimalFormat // the detected format of the decimal
+	Normalized string        // the normalized decimal
+}
+
+// FindAll returns all the decimals found in the text, in order.
+// A decimal is a run of digits and separators that is not glued to a word
+// (as in "v1.2" or "12kg") nor part of a date or a time ("2020-01-05", "12:30"),
+// and is a valid, non ambiguous, decimal string.
+// If the whole run is not valid, its longest valid prefix ending before a space is used:
+// "1.5 2.5" contains two decimals.
+// Example:
+//
+//	FindAll("Total: 1 234,50 €") => [{7 15 {`,`, ` `, standard} 1234.5}]
+func FindAll(text string) []Match {
+	var matches []Match
+	for i := 0; i < len(text); {
+		start, end, normalized, df := nextDecimal(text, i, true)
+		if start == len(text) {
+			break
+		}
+		matches = append(matches, Match{Start: start, End: end, Format: df, Normalized: normalized})
+		i = end
+	}
+	return matches
+}
+
+// ScanDecimals is a split function for a bufio.Scanner that returns each decimal
+// of the input (see FindAll), normalized. The decimals split across the reads of the
+// input, including their multi-byte separators like '·', are found like in a single text.
+// The returned token may point to the data of the scanner, like the other split functions.
+func ScanDecimals(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start, end, normalized, _ := nextDecimal(data, 0, atEOF)
+	switch {
+	case end >= 0 && start < len(data):
+		// skip the runs glued to the decimal, like "+2" and "-3" in "1.5+2-3",
+		// as the next call cannot see the digit preceding them
+		advance = end
+		for advance < len(data) && (data[advance] == '+' || data[advance] == '-') && isDigitAt(data, advance+1) {
+			next := runEnd(data, advance)
+			if !atEOF && next+utf8.UTFMax > len(data) {
+				// request more data to find the end of the glued run
+				return 0, nil, nil
+			}
+			advance = next
+		}
+		return advance, normalized, nil
+	case atEOF:
+		return len(data), nil, nil
+	}
+	return scanCut(data, start), nil, nil
+}
+
+// scanCut returns the position before limit where the data can be cut without
+// changing the decimals found after it: the start of a rune that is not a digit,
+// a sign or a separator, so that it is only the context of the following decimal.
+// It returns 0 if there is no such position.
+func scanCut(data []byte, limit int) int {
+	for q := limit - 1; q > 0; q-- {
+		if !utf8.RuneStart(data[q]) || isDigitAt(data, q) || data[q] == '-' || data[q] == '+' {
+			continue
+		}
+		if _, ok := isSeparatorAt(data, q); !ok {
+			return q
+		}
+	}
+	return 0
+}
+
+// ReplaceAll returns a copy of the text where all the decimals (see FindAll)
+// are converted to the DecimalFormat, leaving everything else untouched.
+// Unlike Convert, an explicit '+' sign is kept. The decimals that cannot be converted
+// (all of them if the DecimalFormat is not valid, or those with a fractional part
+// if it has no decimal separator) are left unchanged.
+// Example:
+//
+//	{`.`, `,`, standard}.ReplaceAll("Total: 1.234,50 €") => "Total: 1,234.5 €"
+func (df DecimalFormat) ReplaceAll(text string) string {
+	matches := FindAll(text)
+	if len(matches) == 0 {
+		return text
+	}
+	sb := strings.Builder{}
+	sb.Grow(len(text))
+	last := 0
+	for _, m := range matches {
+		sb.WriteString(text[last:m.Start])
+		last = m.End
+		converted, ok := df.Convert(m.Normalized)
+		if !ok {
+			sb.WriteString(text[m.Start:m.End])
+			continue
+		}
+		// keep the explicit '+' sign, dropped by Convert
+		if text[m.Start] == '+' {
+			sb.WriteByte('+')
+		}
+		sb.WriteString(converted)
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// ParsePrefix parses the longest valid decimal at the start of s and returns
+// its normalized value, its format and its length in bytes.
+// The decimal must start with a digit, optionally preceded by a sign,
+// and ends before the first byte that cannot continue it.
+// If there is no valid decimal at the start of s, ok is false.
+// Note that ambiguous strings are not valid, so the longest valid decimal
+// at the start of "1,234" is "1".
+// Example:
+//
+//	ParsePrefix("1 234,5 €") => "1234.5", {`,`, ` `, standard}, 7, true
+//	ParsePrefix("12.5;3")    => "12.5", {`.`, `<none>`, standard}, 4, true
+//	ParsePrefix("abc")       => "", {}, 0, false
+func ParsePrefix(s string) (normalized string, df DecimalFormat, n int, ok bool) {
+	signed := len(s) > 0 && (s[0] == '-' || s[0] == '+')
+	if !isDigitAt(s, 0) && !(signed && isDigitAt(s, 1)) {
+		return "", df, 0, false
+	}
+	for end := runEnd(s, 0); end > 0; end = lastSeparator(s, end) {
+		if normalized, df, ok = detectAndNormalize(s[:end]); ok {
+			return normalized, df, end, true
+		}
+	}
+	return "", DecimalFormat{}, 0, false
+}
+
+// lastSeparator returns the position of the last separator in text[:end], or 0 if there is none.
+func lastSeparator[T bytestr](text T, end int) int {
+	for i := end - 1; i > 0; i-- {
+		if _, ok := isSeparatorAt(text, i); ok {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+package decstr
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFindAll(t *testing.T) {
+	var (
+		plain = DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}
+		fr    = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+		en    = DecimalFormat{Point: '.', Group: ',', Standard: true}
+	)
+	tests := []struct {
+		text string
+		want []Match
+	}{
+		{"", nil},
+		{"nothing", nil},
+		{"42", []Match{{0, 2, plain, "42"}}},
+		{"Total: 1 234,50 €", []Match{{7, 15, fr, "1234.5"}}},
+		{"1\u00A0234,50", []Match{{0, 9, fr, "1234.5"}}},
+		{"x 12\u202F345\u202F678 y", []Match{{2, 16, DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "12345678"}}},
+		{"1\u00A0234\u00A05", []Match{{0, 6, DecimalFormat{Point: NoSeparator, Group: ' ', Standard: true}, "1234"}, {8, 9, plain, "5"}}},
+		{"Total 1_234.5", []Match{{6, 13, DecimalFormat{Point: '.', Group: '_', Standard: true}, "1234.5"}}},
+		{"x_12 and 1_000_000", []Match{{9, 18, DecimalFormat{Point: NoSeparator, Group: '_', Standard: true}, "1000000"}}},
+		{"a_1 12_", nil},
+		{"Paid $1,234.50, owed -12.", []Match{
+			{6, 14, en, "1234.5"},
+			{21, 24, plain, "-12"},
+		}},
+		{"1.5 2,5", []Match{
+			{0, 3, DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, "1.5"},
+			{4, 7, DecimalFormat{Point: ',', Group: NoSeparator, Standard: true}, "2.5"},
+		}},
+		{"1,234 apples", nil},
+		{"v1.2 12kg 2020-01-05 12:30 é5", nil},
+		{"(½) 3·5", []Match{{5, 9, DecimalFormat{Point: '·', Group: NoSeparator, Standard: true}, "3.5"}}},
+	}
+
+	for _, test := range tests {
+		got := FindAll(test.text)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("FindAll(%q) = %v, want %v", test.text, got, test.want)
+		}
+		for _, m := range got {
+			if n, _ := NormalizeCheck(test.text[m.Start:m.End]); n != m.Normalized {
+				t.Errorf("FindAll(%q): Normalize(%q) = %q, want %q", test.text, test.text[m.Start:m.End], n, m.Normalized)
+			}
+		}
+	}
+}
+
+func ExampleFindAll() {
+	text := "Invoice: 3 items at 1 234,50 € = 3 703,50 €"
+	for _, m := range FindAll(text) {
+		fmt.Printf("%q -> %s\n", text[m.Start:m.End], m.Normalized)
+	}
+	// Output:
+	// "3" -> 3
+	// "1 234,50" -> 1234.5
+	// "3 703,50" -> 3703.5
+}
+
+func TestScanDecimals(t *testing.T) {
+	texts := []string{
+		"",
+		"nothing",
+		"42",
+		"Total: 1 234,50 €",
+		"Paid $1,234.50, owed -12.",
+		"1.5 2,5",
+		"1,234 apples",
+		"v1.2 12kg 2020-01-05 12:30 é5",
+		"(½) 3·5 and 1·25",
+		"1.5+2-3 and 7",
+		"日本12 円 3·5円 4",
+		"x12345678901234567890 1 234 567,5",
+		"Total 1\u00A0234,50 € and 12\u202F345\u202F678\u202F",
+	}
+
+	for _, text := range texts {
+		var want []string
+		for _, m := range FindAll(text) {
+			want = append(want, m.Normalized)
+		}
+		// read one byte at a time to split the decimals and their separators
+		scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+		scanner.Split(ScanDecimals)
+		var got []string
+		for scanner.Scan() {
+			got = append(got, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			t.Errorf("ScanDecimals(%q): %v", text, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("ScanDecimals(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func ExampleScanDecimals() {
+	scanner := bufio.NewScanner(strings.NewReader("Total: 1 234,50 € (incl. 205,75 € VAT)"))
+	scanner.Split(ScanDecimals)
+	for scanner.Scan() {
+		fmt.Println(scanner.Text())
+	}
+	// Output:
+	// 1234.5
+	// 205.75
+}
+
+func TestReplaceAll(t *testing.T) {
+	var (
+		en = DecimalFormat{Point: '.', Group: ',', Standard: true}
+		fr = DecimalFormat{Point: ',', Group: ' ', Standard: true}
+	)
+	tests := []struct {
+		df   DecimalFormat
+		text string
+		want string
+	}{
+		{en, "", ""},
+		{en, "no numbers", "no numbers"},
+		{en, "Summe: 1.234,50 € und -12,5 %", "Summe: 1,234.5 € und -12.5 %"},
+		{en, "1 234 567", "1,234,567"},
+		{en, "1,234 stays", "1,234 stays"},
+		{fr, "v1.2 costs 1234.5 on 2020-01-05", "v1.2 costs 1 234,5 on 2020-01-05"},
+		{fr, "1.5 2.5", "1,5 2,5"},
+		{en, "Summe: 1\u00A0234,50 €", "Summe: 1,234.5 €"},
+		{en, "12\u202F345\u202F678", "12,345,678"},
+		{fr, "Total 1_234.5 and id_12", "Total 1 234,5 and id_12"},
+		{DecimalFormat{Point: NoSeparator, Group: ',', Standard: true}, "price 1.5 and 2000", "price 1.5 and 2,000"},
+		{DecimalFormat{Point: 'x', Group: ','}, "1.5 and +2", "1.5 and +2"},
+	}
+
+	for _, test := range tests {
+		got := test.df.ReplaceAll(test.text)
+		if got != test.want {
+			t.Errorf("(%v).ReplaceAll(%q) = %q, want %q", test.df, test.text, got, test.want)
+		}
+	}
+}
+
+func ExampleDecimalFormat_ReplaceAll() {
+	en := DecimalFormat{Point: '.', Group: ',', Standard: true}
+	fmt.Println(en.ReplaceAll("Umsatz: 1.234.567,89 € (+12,5 %)"))
+	// Output: Umsatz: 1,234,567.89 € (+12.5 %)
+}
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		s          string
+		normalized string
+		df         DecimalFormat
+		n          int
+		ok         bool
+	}{
+		{"", "", DecimalFormat{}, 0, false},
+		{"abc", "", DecimalFormat{}, 0, false},
+		{"-", "", DecimalFormat{}, 0, false},
+		{" 12", "", DecimalFormat{}, 0, false},
+		{"12", "12", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, 2, true},
+		{"1 234,5 €", "1234.5", DecimalFormat{Point: ',', Group: ' ', Standard: true}, 7, true},
+		{"12.5;3", "12.5", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, 4, true},
+		{"-1,234.5.6", "-1234.5", DecimalFormat{Point: '.', Group: ',', Standard: true}, 8, true},
+		{"+3·25x", "3.25", DecimalFormat{Point: '·', Group: NoSeparator, Standard: true}, 6, true},
+		{"1,234", "1", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, 1, true},
+		{"1.5 2.5", "1.5", DecimalFormat{Point: '.', Group: NoSeparator, Standard: true}, 3, true},
+		{"12,", "12", DecimalFormat{Point: NoSeparator, Group: NoSeparator, Standard: true}, 2, true},
+	}
+
+	for _, test := range tests {
+		normalized, df, n, ok := ParsePrefix(test.s)
+		if normalized != test.normalized || df != test.df || n != test.n || ok != test.ok {
+			t.Errorf("ParsePrefix(%q) = (%q, %v, %d, %v), want (%q, %v, %d, %v)", test.s, normalized, df, n, ok, test.normalized, test.df, test.n, test.ok)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package decstr
+
+import "strings"
+
+// ParseWithUnit parses a decimal string followed by a unit, like "12,5 kg" or "3.5%".
+// It returns the normalized decimal and the unit (without the surrounding spaces).
+// The unit starts at the first character that cannot be part of a decimal string
+// (i.e. that is not a space, a sign, a digit or a separator), so it can contain digits ("m2").
+// If there is no unit, unit is empty.
+// The boolean `ok` is false if the decimal part is not a valid decimal string (see NormalizeCheck).
+// Example:
+//
+//	ParseWithUnit("12,5 kg")   => "12.5", "kg", true
+//	ParseWithUnit("1 234 m2")  => "1234", "m2", true
+//	ParseWithUnit("12")        => "12", "", true
+//	ParseWithUnit("kg")        => "", "", false
+func ParseWithUnit(s string) (value, unit string, ok bool) {
+	i := unitStart(s)
+	value, ok = NormalizeCheck(s[:i])
+	if !ok {
+		return "", "", false
+	}
+	return value, strings.TrimSpace(s[i:]), true
+}
+
+// unitStart returns the position of the first character of s that cannot be part
+// of a decimal string, or len(s) if there is none.
+func unitStart(s string) int {
+	for i := 0; i < len(s); i++ {
+		if isDigitAt(s, i) || s[i] == '-' || s[i] == '+' {
+			continue
+		}
+		n, ok := isSeparatorAt(s, i)
+		if !ok {
+			return i
+		}
+		// a Unicode space is only part of the decimal between digits (Parse does not trim it)
+		if _, space := isSpaceAt(s, i); space && n > 1 && !isDigitAt(s, i+n) {
+			return i
+		}
+		i += n - 1
+	}
+	return len(s)
+}
@@ -0,0 +1,44 @@
+package decstr
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseWithUnit(t *testing.T) {
+	tests := []struct {
+		s     string
+		value string
+		unit  string
+		ok    bool
+	}{
+		{"", "", "", false},
+		{"kg", "", "", false},
+		{"12", "12", "", true},
+		{"12,5 kg", "12.5", "kg", true},
+		{" -1 234,50kg ", "-1234.5", "kg", true},
+		{"1 234 m2", "1234", "m2", true},
+		{"1\u00A0234 kg", "1234", "kg", true},
+		{"1\u202F234\u202F567,5\u202Fkm", "1234567.5", "km", true},
+		{"12\u00A0kg", "12", "kg", true},
+		{"3.5%", "3.5", "%", true},
+		{"1,234.5 km/h", "1234.5", "km/h", true},
+		{"12·5 µm", "12.5", "µm", true},
+		{"1,234 kg", "", "", false}, // ambiguous
+		{"12,5, kg", "", "", false},
+		{"1-2 kg", "", "", false},
+	}
+
+	for _, test := range tests {
+		value, unit, ok := ParseWithUnit(test.s)
+		if value != test.value || unit != test.unit || ok != test.ok {
+			t.Errorf("ParseWithUnit(%q) = (%q, %q, %v), want (%q, %q, %v)", test.s, value, unit, ok, test.value, test.unit, test.ok)
+		}
+	}
+}
+
+func ExampleParseWithUnit() {
+	value, unit, ok := ParseWithUnit("1 234,5 kg")
+	fmt.Println(value, unit, ok)
+	// Output: 1234.5 kg true
+}